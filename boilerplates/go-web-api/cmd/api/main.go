@@ -1,15 +1,29 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/yourusername/go-web-api/internal/cache"
 	"github.com/yourusername/go-web-api/internal/config"
 	"github.com/yourusername/go-web-api/internal/database"
+	"github.com/yourusername/go-web-api/internal/email"
 	"github.com/yourusername/go-web-api/internal/handlers"
+	"github.com/yourusername/go-web-api/internal/jobs"
 	"github.com/yourusername/go-web-api/internal/middleware"
+	"github.com/yourusername/go-web-api/internal/models"
 	"github.com/yourusername/go-web-api/internal/repository"
+	"github.com/yourusername/go-web-api/internal/server"
 	"github.com/yourusername/go-web-api/internal/services"
+	"github.com/yourusername/go-web-api/internal/storage"
+	"github.com/yourusername/go-web-api/internal/telemetry"
+	"github.com/yourusername/go-web-api/internal/websocket"
+	"github.com/yourusername/go-web-api/internal/worker"
+	"github.com/yourusername/go-web-api/pkg/validation"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -39,6 +53,12 @@ func main() {
 	// Initialize logger
 	logger := config.InitLogger(cfg)
 
+	// Initialize tracing
+	shutdownTracing, err := telemetry.InitTracing(cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize tracing")
+	}
+
 	// Initialize database
 	db, err := database.NewPostgresDB(cfg)
 	if err != nil {
@@ -50,57 +70,288 @@ func main() {
 		logger.Fatal().Err(err).Msg("Failed to migrate database")
 	}
 
+	// Initialize cache
+	store := cache.NewNoopCache()
+	if cfg.CacheEnabled {
+		store, err = cache.NewRedisCache(cfg)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to connect to cache")
+		}
+	}
+	defer store.Close()
+
+	cacheTTL, err := time.ParseDuration(cfg.CacheDefaultTTL)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid cache TTL")
+	}
+
+	// Initialize file storage
+	var fileStorage storage.Storage
+	switch cfg.StorageDriver {
+	case "s3":
+		fileStorage, err = storage.NewS3Storage(storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			UseSSL:    cfg.S3UseSSL,
+		})
+	default:
+		fileStorage, err = storage.NewLocalStorage(cfg.StorageLocalDir, "/api/v1/files", cfg.JWTSecret)
+	}
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize file storage")
+	}
+
+	uploadURLExpiry, err := time.ParseDuration(cfg.UploadURLExpiry)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid upload URL expiry")
+	}
+
+	// Initialize email
+	var mailSender email.Sender
+	switch cfg.EmailDriver {
+	case "smtp":
+		mailSender = email.NewSMTPSender(email.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.EmailFrom,
+		})
+	case "ses":
+		mailSender = email.NewSESSender(cfg.SESRegion, cfg.SMTPUsername, cfg.SMTPPassword, cfg.EmailFrom)
+	case "log":
+		if cfg.AppEnv != "development" {
+			logger.Warn().Msg("EMAIL_DRIVER=log writes emails, including password reset links, to the log")
+		}
+		mailSender = email.NewLogSender(logger)
+	case "":
+		logger.Fatal().Str("env", cfg.AppEnv).Msg("EMAIL_DRIVER must be set outside development")
+	default:
+		logger.Fatal().Str("driver", cfg.EmailDriver).Msg("Unknown EMAIL_DRIVER")
+	}
+
+	mailRenderer, err := email.NewRenderer()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load email templates")
+	}
+
+	passwordResetExpiry, err := time.ParseDuration(cfg.PasswordResetExpiry)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid password reset expiry")
+	}
+
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	uploadRepo := repository.NewUploadRepository(db)
+	passwordResetRepo := repository.NewPasswordResetRepository(db)
 
 	// Initialize services
-	userService := services.NewUserService(userRepo)
+	authService := services.NewAuthService(userRepo, sessionRepo, refreshTokenRepo, cfg)
+	userService := services.NewUserService(userRepo, authService, store, cacheTTL)
+	uploadService := services.NewUploadService(uploadRepo, fileStorage, services.UploadConfig{
+		MaxSize:      cfg.UploadMaxSize,
+		AllowedTypes: cfg.UploadAllowedTypes,
+		URLExpiry:    uploadURLExpiry,
+	})
+
+	// Initialize background worker
+	bgWorker := worker.New(worker.Config{
+		Concurrency: cfg.WorkerConcurrency,
+		QueueSize:   cfg.WorkerQueueSize,
+		MaxRetries:  cfg.WorkerMaxRetries,
+	}, logger)
+
+	passwordService := services.NewPasswordService(userRepo, passwordResetRepo, userService, authService, mailRenderer, jobs.NewEmailQueue(bgWorker), services.PasswordConfig{
+		AppName:     cfg.AppName,
+		ResetURL:    cfg.PasswordResetURL,
+		ResetExpiry: passwordResetExpiry,
+	})
+
+	// Initialize WebSocket hub
+	hub := websocket.NewHub(cfg.CORSAllowedOrigins, logger)
+
+	// Register jobs
+	bgWorker.Register(jobs.TypeCleanupSessions, jobs.CleanupSessions(authService, logger))
+	bgWorker.Register(jobs.TypeCleanupPasswordResets, jobs.CleanupPasswordResets(passwordService, logger))
+	bgWorker.Register(jobs.TypeSendEmail, jobs.SendEmail(mailSender))
+	bgWorker.Register(jobs.TypeNotify, jobs.SendNotification(hub))
+
+	// Push job results to connected admins
+	bgWorker.OnFinish(jobs.BroadcastJobFinished(hub, logger))
+
+	// Schedule recurring jobs
+	cleanupInterval, err := time.ParseDuration(cfg.SessionCleanupInterval)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid session cleanup interval")
+	}
+	if err := bgWorker.Schedule("cleanup-sessions", cleanupInterval, jobs.TypeCleanupSessions, nil); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to schedule session cleanup")
+	}
+	if err := bgWorker.Schedule("cleanup-password-resets", cleanupInterval, jobs.TypeCleanupPasswordResets, nil); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to schedule password reset cleanup")
+	}
+
+	bgWorker.Start()
 
 	// Initialize handlers
 	userHandler := handlers.NewUserHandler(userService)
+	authHandler := handlers.NewAuthHandler(authService)
+	passwordHandler := handlers.NewPasswordHandler(passwordService)
 	healthHandler := handlers.NewHealthHandler(db)
+	jobHandler := handlers.NewJobHandler(bgWorker)
+	uploadHandler := handlers.NewUploadHandler(uploadService, cfg.UploadMaxSize)
+	wsHandler := handlers.NewWebSocketHandler(hub, authService, userService)
+
+	// Report validation errors by JSON field name
+	validation.Register()
 
 	// Setup Gin mode
 	if cfg.AppEnv == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// Initialize metrics
+	metrics := telemetry.NewMetrics()
+	if cfg.MetricsEnabled {
+		sqlDB, err := db.DB()
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to get database instance")
+		}
+		if err := metrics.RegisterDB(sqlDB, cfg.DBName); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to register database metrics")
+		}
+	}
+
 	// Create router
 	router := gin.New()
+	router.MaxMultipartMemory = 8 << 20
 
-	// Global middleware
+	// Global middleware. Tracing comes first so the request logger runs inside
+	// the span and can log its trace ID.
+	if cfg.TracingEnabled {
+		router.Use(middleware.Tracing(cfg))
+	}
 	router.Use(middleware.Logger(logger))
+	if cfg.MetricsEnabled {
+		router.Use(middleware.Metrics(metrics))
+	}
 	router.Use(middleware.Recovery(logger))
 	router.Use(middleware.CORS(cfg))
+	router.Use(middleware.HSTS(cfg))
+	router.Use(middleware.ErrorHandler(logger))
 
 	// Health check endpoint
 	router.GET("/health", healthHandler.Check)
 
+	// Prometheus metrics endpoint
+	if cfg.MetricsEnabled {
+		router.GET(cfg.MetricsPath, gin.WrapH(metrics.Handler()))
+	}
+
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
+		// Auth routes
+		auth := v1.Group("/auth")
+		{
+			auth.POST("/login", authHandler.Login)
+			auth.POST("/refresh", authHandler.Refresh)
+			auth.POST("/forgot-password", passwordHandler.ForgotPassword)
+			auth.POST("/reset-password", passwordHandler.ResetPassword)
+
+			authenticated := auth.Group("")
+			authenticated.Use(middleware.Auth(cfg, authService))
+			{
+				authenticated.POST("/logout-all", authHandler.LogoutAll)
+				authenticated.GET("/sessions", authHandler.ListSessions)
+				authenticated.DELETE("/sessions/:id", authHandler.RevokeSession)
+			}
+		}
+
 		// User routes
 		users := v1.Group("/users")
+		users.Use(middleware.OptionalAuth(cfg, authService))
 		{
 			users.GET("", userHandler.List)
-			users.GET("/:id", userHandler.GetByID)
+			users.GET("/:id", middleware.CacheResponse(store, "users", cacheTTL), userHandler.GetByID)
 			users.POST("", userHandler.Create)
 			users.PUT("/:id", userHandler.Update)
 			users.DELETE("/:id", userHandler.Delete)
 		}
 
+		// Upload routes
+		uploads := v1.Group("/uploads")
+		uploads.Use(middleware.Auth(cfg, authService))
+		{
+			uploads.POST("", uploadHandler.Create)
+			uploads.GET("/:id", uploadHandler.GetByID)
+			uploads.DELETE("/:id", uploadHandler.Delete)
+		}
+
+		// Signed file downloads (local storage), authorized by the URL signature
+		v1.GET("/files/*key", uploadHandler.Download)
+
+		// Admin routes
+		admin := v1.Group("/admin")
+		admin.Use(middleware.Auth(cfg, authService), middleware.RequireRole(models.RoleAdmin))
+		{
+			admin.GET("/jobs", jobHandler.List)
+			admin.GET("/jobs/:id", jobHandler.GetByID)
+			admin.GET("/users/deleted", userHandler.ListDeleted)
+			admin.POST("/users/:id/restore", userHandler.Restore)
+		}
+
+		// WebSocket routes
+		v1.GET("/ws/notifications", middleware.WebSocketAuth(cfg, authService), wsHandler.Notifications)
+
 		// Example protected routes
 		protected := v1.Group("/protected")
-		protected.Use(middleware.Auth(cfg))
+		protected.Use(middleware.Auth(cfg, authService))
 		{
 			protected.GET("/profile", userHandler.GetProfile)
 		}
 	}
 
+	// Create server
+	srv, err := server.New(cfg, router, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to configure server")
+	}
+
 	// Start server
-	addr := ":" + cfg.AppPort
-	logger.Info().Msgf("Starting server on %s", addr)
-	if err := router.Run(addr); err != nil {
-		logger.Fatal().Err(err).Msg("Failed to start server")
+	go func() {
+		if err := srv.Start(); err != nil {
+			logger.Fatal().Err(err).Msg("Failed to start server")
+		}
+	}()
+
+	// Wait for interrupt signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.Info().Msg("Shutting down server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Error().Err(err).Msg("Server forced to shutdown")
+	}
+
+	// Hijacked WebSocket connections are not closed by the server shutdown
+	hub.Close()
+
+	if err := bgWorker.Stop(ctx); err != nil {
+		logger.Error().Err(err).Msg("Background worker forced to stop")
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error().Err(err).Msg("Failed to flush traces")
 	}
 }
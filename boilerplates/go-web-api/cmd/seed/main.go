@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/yourusername/go-web-api/internal/config"
+	"github.com/yourusername/go-web-api/internal/database"
+	"github.com/yourusername/go-web-api/internal/seed"
+
+	"github.com/joho/godotenv"
+)
+
+// seed populates the database with the seed sets of an environment. It is
+// safe to run repeatedly: existing records are left untouched.
+//
+// Usage:
+//
+//	go run ./cmd/seed                    # sets for APP_ENV
+//	go run ./cmd/seed -env test -force   # sets for another environment
+//	go run ./cmd/seed -only admin,demo   # specific sets
+//	go run ./cmd/seed -list              # list available sets
+func main() {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using system environment variables")
+	}
+
+	// Load configuration
+	cfg := config.Load()
+
+	env := flag.String("env", cfg.AppEnv, "environment whose seed sets to run (requires -force unless it is APP_ENV)")
+	only := flag.String("only", "", "comma separated seed sets to run instead of the environment's")
+	force := flag.Bool("force", false, "run sets not meant for APP_ENV")
+	list := flag.Bool("list", false, "list the available seed sets and exit")
+	flag.Parse()
+
+	if *list {
+		for _, set := range seed.Sets {
+			fmt.Printf("%-10s %-40s %s\n", set.Name, set.Description, strings.Join(set.Environments, ","))
+		}
+		return
+	}
+
+	// Initialize logger
+	logger := config.InitLogger(cfg)
+
+	// The database always belongs to APP_ENV, so seeding it with another
+	// environment's sets must be explicit
+	if *env != cfg.AppEnv && !*force {
+		logger.Fatal().Str("env", *env).Str("app_env", cfg.AppEnv).Msg("-env differs from APP_ENV; pass -force to seed this database anyway")
+	}
+
+	names := seed.ForEnvironment(*env)
+	if *only != "" {
+		names = nil
+		for _, name := range strings.Split(*only, ",") {
+			names = append(names, strings.TrimSpace(name))
+		}
+	}
+	if len(names) == 0 {
+		logger.Warn().Str("env", *env).Msg("No seed sets for environment")
+		return
+	}
+
+	// Initialize database
+	db, err := database.NewPostgresDB(cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to connect to database")
+	}
+
+	// Auto-migrate database models
+	if err := database.AutoMigrate(db); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to migrate database")
+	}
+
+	if err := seed.Run(db, cfg, logger, names, *force); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to seed database")
+	}
+
+	logger.Info().Strs("sets", names).Msg("Database seeded")
+}
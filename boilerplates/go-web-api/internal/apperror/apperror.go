@@ -0,0 +1,37 @@
+package apperror
+
+import (
+	"net/http"
+)
+
+// Error is an error carrying the HTTP status, code and message to return to
+// the client. Handlers use it for errors that do not come from a service.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Err     error
+}
+
+// New creates a new application error
+func New(status int, code, message string, err error) *Error {
+	return &Error{Status: status, Code: code, Message: message, Err: err}
+}
+
+// BadRequest creates a new 400 application error
+func BadRequest(code, message string, err error) *Error {
+	return New(http.StatusBadRequest, code, message, err)
+}
+
+// Error returns the message of the error
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the wrapped error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type actorKey struct{}
+
+// WithActor returns a context carrying the ID of the user making a change
+func WithActor(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext returns the ID of the user making a change, if any
+func ActorFromContext(ctx context.Context) (uint, bool) {
+	userID, ok := ctx.Value(actorKey{}).(uint)
+	return userID, ok && userID != 0
+}
+
+// RegisterCallbacks registers GORM callbacks that fill the CreatedBy and
+// UpdatedBy fields of models that have them from the actor in the statement
+// context. Run queries with db.WithContext(ctx) for the actor to be seen;
+// changes without an actor leave the columns NULL.
+func RegisterCallbacks(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("audit:create", setCreatedBy); err != nil {
+		return err
+	}
+	return db.Callback().Update().Before("gorm:update").Register("audit:update", setUpdatedBy)
+}
+
+// setCreatedBy records the actor as creator and last updater of new records
+func setCreatedBy(db *gorm.DB) {
+	if db.Statement.Schema == nil {
+		return
+	}
+	userID, ok := ActorFromContext(db.Statement.Context)
+	if !ok {
+		return
+	}
+	if field := db.Statement.Schema.LookUpField("CreatedBy"); field != nil {
+		db.Statement.SetColumn(field.DBName, &userID, true)
+	}
+	if field := db.Statement.Schema.LookUpField("UpdatedBy"); field != nil {
+		db.Statement.SetColumn(field.DBName, &userID, true)
+	}
+}
+
+// setUpdatedBy records the actor, or its absence, as last updater of records
+func setUpdatedBy(db *gorm.DB) {
+	if db.Statement.Schema == nil {
+		return
+	}
+	field := db.Statement.Schema.LookUpField("UpdatedBy")
+	if field == nil {
+		return
+	}
+	var updatedBy *uint
+	if userID, ok := ActorFromContext(db.Statement.Context); ok {
+		updatedBy = &userID
+	}
+	db.Statement.SetColumn(field.DBName, updatedBy, true)
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrCacheMiss = errors.New("cache miss")
+
+// Cache is a key-value store for JSON-encoded values
+type Cache interface {
+	Get(ctx context.Context, key string, dest interface{}) error
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	DeletePrefix(ctx context.Context, prefix string) error
+	Close() error
+}
+
+// Remember returns the cached value for key, or calls fn and caches its result
+// on a miss. Cache errors are not fatal: the value is then loaded from fn.
+func Remember[T any](ctx context.Context, c Cache, key string, ttl time.Duration, fn func() (T, error)) (T, error) {
+	var value T
+	if err := c.Get(ctx, key, &value); err == nil {
+		return value, nil
+	}
+
+	value, err := fn()
+	if err != nil {
+		return value, err
+	}
+
+	_ = c.Set(ctx, key, value, ttl)
+	return value, nil
+}
+
+// UserKey returns the cache key for a user
+func UserKey(id uint) string {
+	return fmt.Sprintf("user:%d", id)
+}
+
+// ResponseKey returns the cache key for a cached HTTP response in a namespace
+func ResponseKey(namespace, requestURI string) string {
+	return ResponsePrefix(namespace) + requestURI
+}
+
+// ResponsePrefix returns the key prefix of all cached HTTP responses in a namespace
+func ResponsePrefix(namespace string) string {
+	return "response:" + namespace + ":"
+}
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+type noopCache struct{}
+
+// NewNoopCache creates a cache that stores nothing, used when caching is disabled
+func NewNoopCache() Cache {
+	return noopCache{}
+}
+
+func (noopCache) Get(ctx context.Context, key string, dest interface{}) error {
+	return ErrCacheMiss
+}
+
+func (noopCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return nil
+}
+
+func (noopCache) Delete(ctx context.Context, keys ...string) error {
+	return nil
+}
+
+func (noopCache) DeletePrefix(ctx context.Context, prefix string) error {
+	return nil
+}
+
+func (noopCache) Close() error {
+	return nil
+}
@@ -15,6 +15,15 @@ type Config struct {
 	AppPort  string
 	AppDebug bool
 
+	TLSEnabled          bool
+	TLSCertFile         string
+	TLSKeyFile          string
+	TLSAutocertDomains  []string
+	TLSAutocertEmail    string
+	TLSAutocertCacheDir string
+	TLSRedirectPort     string
+	HSTSMaxAge          int
+
 	DBHost     string
 	DBPort     string
 	DBUser     string
@@ -25,21 +34,85 @@ type Config struct {
 	JWTSecret string
 	JWTExpiry string
 
+	RefreshTokenExpiry string
+
+	SessionIdleTimeout        string
+	SessionRefreshIdleTimeout string
+	SessionMaxPerUser         int
+
+	WorkerConcurrency      int
+	WorkerQueueSize        int
+	WorkerMaxRetries       int
+	SessionCleanupInterval string
+
+	EmailDriver  string
+	EmailFrom    string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SESRegion    string
+
+	PasswordResetURL    string
+	PasswordResetExpiry string
+
 	CORSAllowedOrigins []string
 	CORSAllowedMethods []string
 	CORSAllowedHeaders []string
 
+	StorageDriver      string
+	StorageLocalDir    string
+	UploadMaxSize      int64
+	UploadAllowedTypes []string
+	UploadURLExpiry    string
+
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3UseSSL    bool
+
+	CacheEnabled    bool
+	CacheDefaultTTL string
+	RedisAddr       string
+	RedisPassword   string
+	RedisDB         int
+
+	TracingEnabled     bool
+	TracingSampleRatio float64
+	OTLPEndpoint       string
+	OTLPInsecure       bool
+
+	MetricsEnabled bool
+	MetricsPath    string
+
+	SeedAdminEmail    string
+	SeedAdminUsername string
+	SeedAdminPassword string
+
 	LogLevel string
 }
 
 // Load reads configuration from environment variables
 func Load() *Config {
+	appEnv := getEnv("APP_ENV", "development")
+
 	return &Config{
 		AppName:  getEnv("APP_NAME", "go-web-api"),
-		AppEnv:   getEnv("APP_ENV", "development"),
+		AppEnv:   appEnv,
 		AppPort:  getEnv("APP_PORT", "8080"),
 		AppDebug: getEnvBool("APP_DEBUG", true),
 
+		TLSEnabled:          getEnvBool("TLS_ENABLED", false),
+		TLSCertFile:         getEnv("TLS_CERT_FILE", ""),
+		TLSKeyFile:          getEnv("TLS_KEY_FILE", ""),
+		TLSAutocertDomains:  getEnvSlice("TLS_AUTOCERT_DOMAINS", nil),
+		TLSAutocertEmail:    getEnv("TLS_AUTOCERT_EMAIL", ""),
+		TLSAutocertCacheDir: getEnv("TLS_AUTOCERT_CACHE_DIR", "certs"),
+		TLSRedirectPort:     getEnv("TLS_REDIRECT_PORT", "80"),
+		HSTSMaxAge:          getEnvInt("HSTS_MAX_AGE", 31536000),
+
 		DBHost:     getEnv("DB_HOST", "localhost"),
 		DBPort:     getEnv("DB_PORT", "5432"),
 		DBUser:     getEnv("DB_USER", "postgres"),
@@ -48,12 +121,65 @@ func Load() *Config {
 		DBSSLMode:  getEnv("DB_SSL_MODE", "disable"),
 
 		JWTSecret: getEnv("JWT_SECRET", "your-secret-key"),
-		JWTExpiry: getEnv("JWT_EXPIRY", "24h"),
+		JWTExpiry: getEnv("JWT_EXPIRY", "15m"),
+
+		RefreshTokenExpiry: getEnv("REFRESH_TOKEN_EXPIRY", "720h"),
+
+		SessionIdleTimeout:        getEnv("SESSION_IDLE_TIMEOUT", "30m"),
+		SessionRefreshIdleTimeout: getEnv("SESSION_REFRESH_IDLE_TIMEOUT", "168h"),
+		SessionMaxPerUser:         getEnvInt("SESSION_MAX_PER_USER", 0),
+
+		WorkerConcurrency:      getEnvInt("WORKER_CONCURRENCY", 4),
+		WorkerQueueSize:        getEnvInt("WORKER_QUEUE_SIZE", 100),
+		WorkerMaxRetries:       getEnvInt("WORKER_MAX_RETRIES", 3),
+		SessionCleanupInterval: getEnv("SESSION_CLEANUP_INTERVAL", "1h"),
+
+		EmailDriver:  getEnv("EMAIL_DRIVER", defaultEmailDriver(appEnv)),
+		EmailFrom:    getEnv("EMAIL_FROM", "go-web-api <no-reply@example.com>"),
+		SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SESRegion:    getEnv("SES_REGION", "us-east-1"),
+
+		PasswordResetURL:    getEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+		PasswordResetExpiry: getEnv("PASSWORD_RESET_EXPIRY", "1h"),
 
 		CORSAllowedOrigins: getEnvSlice("CORS_ALLOWED_ORIGINS", []string{"*"}),
 		CORSAllowedMethods: getEnvSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
 		CORSAllowedHeaders: getEnvSlice("CORS_ALLOWED_HEADERS", []string{"Origin", "Content-Type", "Authorization"}),
 
+		StorageDriver:      getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalDir:    getEnv("STORAGE_LOCAL_DIR", "storage"),
+		UploadMaxSize:      int64(getEnvInt("UPLOAD_MAX_SIZE", 10<<20)),
+		UploadAllowedTypes: getEnvSlice("UPLOAD_ALLOWED_TYPES", []string{"image/jpeg", "image/png", "image/gif", "image/webp", "application/pdf"}),
+		UploadURLExpiry:    getEnv("UPLOAD_URL_EXPIRY", "15m"),
+
+		S3Endpoint:  getEnv("S3_ENDPOINT", "s3.amazonaws.com"),
+		S3Region:    getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:    getEnv("S3_BUCKET", "go-web-api"),
+		S3AccessKey: getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey: getEnv("S3_SECRET_KEY", ""),
+		S3UseSSL:    getEnvBool("S3_USE_SSL", true),
+
+		CacheEnabled:    getEnvBool("CACHE_ENABLED", false),
+		CacheDefaultTTL: getEnv("CACHE_DEFAULT_TTL", "5m"),
+		RedisAddr:       getEnv("REDIS_ADDR", "localhost:6379"),
+		RedisPassword:   getEnv("REDIS_PASSWORD", ""),
+		RedisDB:         getEnvInt("REDIS_DB", 0),
+
+		TracingEnabled:     getEnvBool("TRACING_ENABLED", false),
+		TracingSampleRatio: getEnvFloat("TRACING_SAMPLE_RATIO", 1.0),
+		OTLPEndpoint:       getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4318"),
+		OTLPInsecure:       getEnvBool("OTEL_EXPORTER_OTLP_INSECURE", true),
+
+		MetricsEnabled: getEnvBool("METRICS_ENABLED", true),
+		MetricsPath:    getEnv("METRICS_PATH", "/metrics"),
+
+		SeedAdminEmail:    getEnv("SEED_ADMIN_EMAIL", "admin@example.com"),
+		SeedAdminUsername: getEnv("SEED_ADMIN_USERNAME", "admin"),
+		SeedAdminPassword: getEnv("SEED_ADMIN_PASSWORD", ""),
+
 		LogLevel: getEnv("LOG_LEVEL", "debug"),
 	}
 }
@@ -87,6 +213,16 @@ func InitLogger(cfg *Config) *zerolog.Logger {
 	return &logger
 }
 
+// defaultEmailDriver returns the log driver in development only. The log
+// driver writes full emails, including password reset links, to the log, so
+// other environments must choose a driver explicitly.
+func defaultEmailDriver(appEnv string) string {
+	if appEnv == "development" {
+		return "log"
+	}
+	return ""
+}
+
 // Helper functions
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -106,6 +242,28 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		intValue, err := strconv.Atoi(value)
+		if err != nil {
+			return defaultValue
+		}
+		return intValue
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		floatValue, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return defaultValue
+		}
+		return floatValue
+	}
+	return defaultValue
+}
+
 func getEnvSlice(key string, defaultValue []string) []string {
 	if value := os.Getenv(key); value != "" {
 		var result []string
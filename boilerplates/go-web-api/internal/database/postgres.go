@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/yourusername/go-web-api/internal/audit"
 	"github.com/yourusername/go-web-api/internal/config"
 	"github.com/yourusername/go-web-api/internal/models"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/opentelemetry/tracing"
 )
 
 // NewPostgresDB creates a new PostgreSQL database connection
@@ -39,6 +41,18 @@ func NewPostgresDB(cfg *config.Config) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Record who created and last updated audited records
+	if err := audit.RegisterCallbacks(db); err != nil {
+		return nil, fmt.Errorf("failed to register audit callbacks: %w", err)
+	}
+
+	// Trace queries when tracing is enabled
+	if cfg.TracingEnabled {
+		if err := db.Use(tracing.NewPlugin(tracing.WithoutMetrics())); err != nil {
+			return nil, fmt.Errorf("failed to enable database tracing: %w", err)
+		}
+	}
+
 	// Get underlying SQL database
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -55,8 +69,26 @@ func NewPostgresDB(cfg *config.Config) (*gorm.DB, error) {
 
 // AutoMigrate runs database migrations
 func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+	if err := db.AutoMigrate(
 		&models.User{},
+		&models.Session{},
+		&models.RefreshToken{},
+		&models.Upload{},
+		&models.PasswordResetToken{},
 		// Add more models here as needed
-	)
+	); err != nil {
+		return err
+	}
+
+	// Email and username used to be unique across soft deleted users too.
+	// AutoMigrate does not drop indexes, so remove the old ones.
+	for _, index := range []string{"idx_users_email", "idx_users_username"} {
+		if db.Migrator().HasIndex(&models.User{}, index) {
+			if err := db.Migrator().DropIndex(&models.User{}, index); err != nil {
+				return fmt.Errorf("failed to drop index %s: %w", index, err)
+			}
+		}
+	}
+
+	return nil
 }
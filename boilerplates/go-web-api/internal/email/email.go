@@ -0,0 +1,26 @@
+package email
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrNoRecipients = errors.New("email has no recipients")
+
+// Message represents an email with an HTML body and a plain text alternative
+type Message struct {
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	HTML    string   `json:"html"`
+	Text    string   `json:"text"`
+}
+
+// Sender delivers emails
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Queue hands emails over for asynchronous delivery
+type Queue interface {
+	Enqueue(msg *Message) error
+}
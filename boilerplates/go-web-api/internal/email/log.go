@@ -0,0 +1,32 @@
+package email
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+// LogSender writes emails to the log instead of delivering them. It is meant
+// for local development.
+type LogSender struct {
+	logger *zerolog.Logger
+}
+
+// NewLogSender creates a new log sender
+func NewLogSender(logger *zerolog.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+// Send logs the email
+func (s *LogSender) Send(ctx context.Context, msg *Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	s.logger.Info().
+		Strs("to", msg.To).
+		Str("subject", msg.Subject).
+		Str("body", msg.Text).
+		Msg("Email (not sent)")
+	return nil
+}
@@ -0,0 +1,85 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// Renderer renders the embedded email templates. Each email has an HTML
+// template rendered inside layout.html and a plain text template.
+type Renderer struct {
+	html map[string]*htmltemplate.Template
+	text map[string]*texttemplate.Template
+}
+
+// NewRenderer parses the embedded email templates
+func NewRenderer() (*Renderer, error) {
+	r := &Renderer{
+		html: make(map[string]*htmltemplate.Template),
+		text: make(map[string]*texttemplate.Template),
+	}
+
+	entries, err := fs.ReadDir(templateFS, "templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email templates: %w", err)
+	}
+
+	for _, entry := range entries {
+		file := path.Join("templates", entry.Name())
+		ext := path.Ext(entry.Name())
+		name := strings.TrimSuffix(entry.Name(), ext)
+
+		switch {
+		case ext == ".html" && name != "layout":
+			t, err := htmltemplate.ParseFS(templateFS, "templates/layout.html", file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse email template %s: %w", entry.Name(), err)
+			}
+			r.html[name] = t
+		case ext == ".txt":
+			t, err := texttemplate.ParseFS(templateFS, file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse email template %s: %w", entry.Name(), err)
+			}
+			r.text[name] = t
+		}
+	}
+
+	return r, nil
+}
+
+// Render builds a message from the named templates
+func (r *Renderer) Render(name, subject string, to []string, data interface{}) (*Message, error) {
+	htmlTmpl, ok := r.html[name]
+	if !ok {
+		return nil, fmt.Errorf("email template %q not found", name)
+	}
+
+	var html bytes.Buffer
+	if err := htmlTmpl.ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render email template %s: %w", name, err)
+	}
+
+	var text bytes.Buffer
+	if textTmpl, ok := r.text[name]; ok {
+		if err := textTmpl.Execute(&text, data); err != nil {
+			return nil, fmt.Errorf("failed to render email template %s: %w", name, err)
+		}
+	}
+
+	return &Message{
+		To:      to,
+		Subject: subject,
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
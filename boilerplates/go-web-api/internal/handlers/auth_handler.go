@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/yourusername/go-web-api/internal/models"
+	"github.com/yourusername/go-web-api/internal/services"
+	"github.com/yourusername/go-web-api/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthHandler handles HTTP requests for authentication and sessions
+type AuthHandler struct {
+	service services.AuthService
+}
+
+// NewAuthHandler creates a new auth handler
+func NewAuthHandler(service services.AuthService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+// Login godoc
+// @Summary Log in
+// @Description Authenticate with email and password and open a new session
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body models.LoginRequest true "Login request"
+// @Success 200 {object} response.Response{data=models.LoginResponse}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req models.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	result, err := h.service.Login(c.Request.Context(), &req, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Logged in successfully", result)
+}
+
+// Refresh godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access and refresh token pair
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body models.RefreshRequest true "Refresh request"
+// @Success 200 {object} response.Response{data=models.LoginResponse}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req models.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	result, err := h.service.Refresh(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Token refreshed successfully", result)
+}
+
+// LogoutAll godoc
+// @Summary Log out of all devices
+// @Description Revoke every session and refresh token of the currently authenticated user
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	if err := h.service.LogoutAll(c.Request.Context(), c.GetUint("user_id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Logged out of all devices successfully", nil)
+}
+
+// ListSessions godoc
+// @Summary List active sessions
+// @Description Get the active sessions of the currently authenticated user
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.Response{data=[]models.SessionResponse}
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	sessions, err := h.service.ListSessions(c.Request.Context(), c.GetUint("user_id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Convert to response format
+	currentSessionID := c.GetString("session_id")
+	sessionResponses := make([]models.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		sessionResponses[i] = *session.ToResponse(currentSessionID)
+	}
+
+	response.Success(c, http.StatusOK, "Sessions retrieved successfully", sessionResponses)
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Revoke one of the sessions of the currently authenticated user
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	if err := h.service.RevokeSession(c.Request.Context(), c.GetUint("user_id"), c.Param("id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Session revoked successfully", nil)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/yourusername/go-web-api/internal/worker"
+	"github.com/yourusername/go-web-api/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobHandler handles HTTP requests for background job status
+type JobHandler struct {
+	worker *worker.Worker
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(w *worker.Worker) *JobHandler {
+	return &JobHandler{worker: w}
+}
+
+// JobListResponse represents the background job status response
+type JobListResponse struct {
+	Stats worker.Stats `json:"stats"`
+	Jobs  []worker.Job `json:"jobs"`
+}
+
+// List godoc
+// @Summary List background jobs
+// @Description Get worker statistics and recent jobs, optionally filtered by status
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "Job status" Enums(scheduled, queued, running, retrying, succeeded, failed)
+// @Success 200 {object} response.Response{data=JobListResponse}
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /admin/jobs [get]
+func (h *JobHandler) List(c *gin.Context) {
+	status := worker.JobStatus(c.Query("status"))
+
+	response.Success(c, http.StatusOK, "Jobs retrieved successfully", JobListResponse{
+		Stats: h.worker.Stats(),
+		Jobs:  h.worker.Jobs(status),
+	})
+}
+
+// GetByID godoc
+// @Summary Get a background job
+// @Description Get the status of a single background job
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} response.Response{data=worker.Job}
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /admin/jobs/{id} [get]
+func (h *JobHandler) GetByID(c *gin.Context) {
+	job, err := h.worker.Job(c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job retrieved successfully", job)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/yourusername/go-web-api/internal/models"
+	"github.com/yourusername/go-web-api/internal/services"
+	"github.com/yourusername/go-web-api/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordHandler handles HTTP requests for password resets
+type PasswordHandler struct {
+	service services.PasswordService
+}
+
+// NewPasswordHandler creates a new password handler
+func NewPasswordHandler(service services.PasswordService) *PasswordHandler {
+	return &PasswordHandler{service: service}
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Email a password reset link. The response is the same whether or not the email is registered.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.ForgotPasswordRequest true "Forgot password request"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/forgot-password [post]
+func (h *PasswordHandler) ForgotPassword(c *gin.Context) {
+	var req models.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.service.ForgotPassword(c.Request.Context(), &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "If the email is registered, a password reset link has been sent", nil)
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password with a reset token. All sessions of the user are revoked.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.ResetPasswordRequest true "Reset password request"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/reset-password [post]
+func (h *PasswordHandler) ResetPassword(c *gin.Context) {
+	var req models.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.service.ResetPassword(c.Request.Context(), &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Password reset successfully", nil)
+}
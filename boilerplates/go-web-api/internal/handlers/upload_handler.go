@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/yourusername/go-web-api/internal/apperror"
+	"github.com/yourusername/go-web-api/internal/services"
+	"github.com/yourusername/go-web-api/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead allows for multipart headers and boundaries on top of the file size
+const multipartOverhead = 1 << 20
+
+// UploadHandler handles HTTP requests for file uploads
+type UploadHandler struct {
+	service services.UploadService
+	maxSize int64
+}
+
+// NewUploadHandler creates a new upload handler
+func NewUploadHandler(service services.UploadService, maxSize int64) *UploadHandler {
+	return &UploadHandler{service: service, maxSize: maxSize}
+}
+
+// Create godoc
+// @Summary Upload a file
+// @Description Upload a file as multipart form data in the "file" field
+// @Tags uploads
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "File to upload"
+// @Success 201 {object} response.Response{data=models.UploadResponse}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 415 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /uploads [post]
+func (h *UploadHandler) Create(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize+multipartOverhead)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			_ = c.Error(services.ErrFileTooLarge)
+		} else {
+			_ = c.Error(apperror.BadRequest("FILE_REQUIRED", "A file is required in the \"file\" field", err))
+		}
+		return
+	}
+
+	upload, err := h.service.Upload(c.Request.Context(), c.GetUint("user_id"), file)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	url, err := h.service.DownloadURL(c.Request.Context(), upload)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "File uploaded successfully", upload.ToResponse(url))
+}
+
+// GetByID godoc
+// @Summary Get an upload
+// @Description Get upload metadata with a signed, expiring download URL
+// @Tags uploads
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Upload ID"
+// @Success 200 {object} response.Response{data=models.UploadResponse}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /uploads/{id} [get]
+func (h *UploadHandler) GetByID(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	upload, err := h.service.GetByID(c.Request.Context(), c.GetUint("user_id"), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	url, err := h.service.DownloadURL(c.Request.Context(), upload)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Upload retrieved successfully", upload.ToResponse(url))
+}
+
+// Delete godoc
+// @Summary Delete an upload
+// @Description Delete an upload and its stored file
+// @Tags uploads
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Upload ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /uploads/{id} [delete]
+func (h *UploadHandler) Delete(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), c.GetUint("user_id"), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Upload deleted successfully", nil)
+}
+
+// Download godoc
+// @Summary Download a file
+// @Description Download a file through a signed URL issued by the local storage backend
+// @Tags uploads
+// @Produce octet-stream
+// @Param key path string true "File key"
+// @Param expires query int true "Expiry as Unix timestamp"
+// @Param filename query string true "Download filename"
+// @Param signature query string true "URL signature"
+// @Success 200 {file} file
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /files/{key} [get]
+func (h *UploadHandler) Download(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	filename := c.Query("filename")
+
+	r, upload, err := h.service.OpenSigned(c.Request.Context(), key, c.Query("expires"), filename, c.Query("signature"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer r.Close()
+
+	// Serve the type detected at upload time, never one derived from the
+	// client's filename, and only let browsers render images inline
+	contentType := upload.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	disposition := "attachment"
+	if strings.HasPrefix(contentType, "image/") {
+		disposition = "inline"
+	}
+
+	c.DataFromReader(http.StatusOK, -1, contentType, r, map[string]string{
+		"Content-Disposition":    fmt.Sprintf("%s; filename=%q", disposition, filename),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
@@ -1,12 +1,13 @@
 package handlers
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/yourusername/go-web-api/internal/apperror"
 	"github.com/yourusername/go-web-api/internal/models"
 	"github.com/yourusername/go-web-api/internal/services"
+	"github.com/yourusername/go-web-api/pkg/query"
 	"github.com/yourusername/go-web-api/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -37,20 +38,13 @@ func NewUserHandler(service services.UserService) *UserHandler {
 func (h *UserHandler) Create(c *gin.Context) {
 	var req models.UserCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid request body", err)
+		_ = c.Error(err)
 		return
 	}
 
-	user, err := h.service.Create(&req)
+	user, err := h.service.Create(c.Request.Context(), &req)
 	if err != nil {
-		switch {
-		case errors.Is(err, services.ErrEmailAlreadyExists):
-			response.Error(c, http.StatusConflict, "Email already exists", err)
-		case errors.Is(err, services.ErrUsernameAlreadyExists):
-			response.Error(c, http.StatusConflict, "Username already exists", err)
-		default:
-			response.Error(c, http.StatusInternalServerError, "Failed to create user", err)
-		}
+		_ = c.Error(err)
 		return
 	}
 
@@ -69,42 +63,63 @@ func (h *UserHandler) Create(c *gin.Context) {
 // @Failure 500 {object} response.Response
 // @Router /users/{id} [get]
 func (h *UserHandler) GetByID(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, err := parseID(c)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid user ID", err)
+		_ = c.Error(err)
 		return
 	}
 
-	user, err := h.service.GetByID(uint(id))
+	user, err := h.service.GetByID(c.Request.Context(), id)
 	if err != nil {
-		if errors.Is(err, services.ErrUserNotFound) {
-			response.Error(c, http.StatusNotFound, "User not found", err)
-		} else {
-			response.Error(c, http.StatusInternalServerError, "Failed to get user", err)
-		}
+		_ = c.Error(err)
 		return
 	}
 
 	response.Success(c, http.StatusOK, "User retrieved successfully", user.ToResponse())
 }
 
+// userListOptions defines the sortable and filterable fields of the users list
+var userListOptions = query.Options{
+	DefaultLimit: 10,
+	MaxLimit:     100,
+	DefaultSort:  "-created_at",
+	SortableFields: map[string]string{
+		"id":         "id",
+		"email":      "email",
+		"username":   "username",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	FilterableFields: map[string]query.FilterField{
+		"email":     {Column: "email", Type: query.FilterString},
+		"username":  {Column: "username", Type: query.FilterString},
+		"is_active": {Column: "is_active", Type: query.FilterBool},
+	},
+}
+
 // List godoc
 // @Summary List all users
-// @Description Get a paginated list of all users
+// @Description Get a filtered, sorted and paginated list of all users
 // @Tags users
 // @Produce json
 // @Param page query int false "Page number" default(1)
-// @Param page_size query int false "Number of items per page" default(10)
-// @Success 200 {object} response.PaginatedResponse{data=[]models.UserResponse}
+// @Param limit query int false "Number of items per page" default(10)
+// @Param sort query string false "Comma separated sort fields, prefix with - for descending" default(-created_at)
+// @Param filter[is_active] query bool false "Filter by active status"
+// @Success 200 {object} response.ListResponse{data=[]models.UserResponse}
+// @Failure 400 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /users [get]
 func (h *UserHandler) List(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	params, err := query.Parse(c, userListOptions)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 
-	users, total, err := h.service.List(page, pageSize)
+	users, total, err := h.service.List(c.Request.Context(), params)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to list users", err)
+		_ = c.Error(err)
 		return
 	}
 
@@ -114,12 +129,12 @@ func (h *UserHandler) List(c *gin.Context) {
 		userResponses[i] = *user.ToResponse()
 	}
 
-	response.Paginated(c, http.StatusOK, "Users retrieved successfully", userResponses, page, pageSize, int(total))
+	response.List(c, http.StatusOK, "Users retrieved successfully", userResponses, params.Page, params.Limit, total)
 }
 
 // Update godoc
 // @Summary Update a user
-// @Description Update user information
+// @Description Update user information. Send the version the change is based on to reject concurrent modifications.
 // @Tags users
 // @Accept json
 // @Produce json
@@ -128,34 +143,25 @@ func (h *UserHandler) List(c *gin.Context) {
 // @Success 200 {object} response.Response{data=models.UserResponse}
 // @Failure 400 {object} response.Response
 // @Failure 404 {object} response.Response
-// @Failure 409 {object} response.Response
+// @Failure 409 {object} response.Response "Email or username taken, or version conflict"
 // @Failure 500 {object} response.Response
 // @Router /users/{id} [put]
 func (h *UserHandler) Update(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, err := parseID(c)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid user ID", err)
+		_ = c.Error(err)
 		return
 	}
 
 	var req models.UserUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid request body", err)
+		_ = c.Error(err)
 		return
 	}
 
-	user, err := h.service.Update(uint(id), &req)
+	user, err := h.service.Update(c.Request.Context(), id, &req)
 	if err != nil {
-		switch {
-		case errors.Is(err, services.ErrUserNotFound):
-			response.Error(c, http.StatusNotFound, "User not found", err)
-		case errors.Is(err, services.ErrEmailAlreadyExists):
-			response.Error(c, http.StatusConflict, "Email already exists", err)
-		case errors.Is(err, services.ErrUsernameAlreadyExists):
-			response.Error(c, http.StatusConflict, "Username already exists", err)
-		default:
-			response.Error(c, http.StatusInternalServerError, "Failed to update user", err)
-		}
+		_ = c.Error(err)
 		return
 	}
 
@@ -174,24 +180,87 @@ func (h *UserHandler) Update(c *gin.Context) {
 // @Failure 500 {object} response.Response
 // @Router /users/{id} [delete]
 func (h *UserHandler) Delete(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, err := parseID(c)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid user ID", err)
+		_ = c.Error(err)
 		return
 	}
 
-	if err := h.service.Delete(uint(id)); err != nil {
-		if errors.Is(err, services.ErrUserNotFound) {
-			response.Error(c, http.StatusNotFound, "User not found", err)
-		} else {
-			response.Error(c, http.StatusInternalServerError, "Failed to delete user", err)
-		}
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
 	response.Success(c, http.StatusOK, "User deleted successfully", nil)
 }
 
+// ListDeleted godoc
+// @Summary List deleted users
+// @Description Get a filtered, sorted and paginated list of soft deleted users
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Param sort query string false "Comma separated sort fields, prefix with - for descending" default(-created_at)
+// @Success 200 {object} response.ListResponse{data=[]models.UserResponse}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/users/deleted [get]
+func (h *UserHandler) ListDeleted(c *gin.Context) {
+	params, err := query.Parse(c, userListOptions)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	users, total, err := h.service.ListDeleted(c.Request.Context(), params)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	userResponses := make([]models.UserResponse, len(users))
+	for i, user := range users {
+		userResponses[i] = *user.ToResponse()
+	}
+
+	response.List(c, http.StatusOK, "Deleted users retrieved successfully", userResponses, params.Page, params.Limit, total)
+}
+
+// Restore godoc
+// @Summary Restore a deleted user
+// @Description Undo the soft delete of a user
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} response.Response{data=models.UserResponse}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/users/{id}/restore [post]
+func (h *UserHandler) Restore(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := h.service.Restore(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User restored successfully", user.ToResponse())
+}
+
 // GetProfile godoc
 // @Summary Get current user profile
 // @Description Get the profile of the currently authenticated user
@@ -202,15 +271,22 @@ func (h *UserHandler) Delete(c *gin.Context) {
 // @Failure 401 {object} response.Response
 // @Router /protected/profile [get]
 func (h *UserHandler) GetProfile(c *gin.Context) {
-	// This would typically get user ID from JWT claims
-	// For now, it's a placeholder
 	userID := c.GetUint("user_id")
 
-	user, err := h.service.GetByID(userID)
+	user, err := h.service.GetByID(c.Request.Context(), userID)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to get profile", err)
+		_ = c.Error(err)
 		return
 	}
 
 	response.Success(c, http.StatusOK, "Profile retrieved successfully", user.ToResponse())
 }
+
+// parseID parses the id path parameter
+func parseID(c *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, apperror.BadRequest("INVALID_ID", "Invalid ID", err)
+	}
+	return uint(id), nil
+}
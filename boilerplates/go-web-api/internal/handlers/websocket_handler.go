@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/yourusername/go-web-api/internal/models"
+	"github.com/yourusername/go-web-api/internal/services"
+	"github.com/yourusername/go-web-api/internal/websocket"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebSocketHandler handles WebSocket connections
+type WebSocketHandler struct {
+	hub         *websocket.Hub
+	authService services.AuthService
+	userService services.UserService
+}
+
+// NewWebSocketHandler creates a new WebSocket handler
+func NewWebSocketHandler(hub *websocket.Hub, authService services.AuthService, userService services.UserService) *WebSocketHandler {
+	return &WebSocketHandler{hub: hub, authService: authService, userService: userService}
+}
+
+// Notifications godoc
+// @Summary Subscribe to notifications
+// @Description Open a WebSocket receiving the user's notifications; admins also receive background job events. Pass the access token as Bearer header or token query parameter.
+// @Tags notifications
+// @Security BearerAuth
+// @Param token query string false "Access token"
+// @Success 101 "Switching Protocols"
+// @Failure 400 {string} string "Not a WebSocket handshake"
+// @Failure 401 {object} response.Response
+// @Router /ws/notifications [get]
+func (h *WebSocketHandler) Notifications(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	rooms := []string{websocket.UserRoom(userID)}
+	if c.GetString("role") == models.RoleAdmin {
+		rooms = append(rooms, websocket.RoomAdmin)
+	}
+
+	// The upgrader answers failed handshakes itself
+	check := h.sessionCheck(userID, c.GetString("session_id"))
+	if err := h.hub.Serve(c.Writer, c.Request, check, rooms...); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// sessionCheck returns a check that keeps a connection open only while its
+// session is valid and its user is active, so logging out, revoking the
+// session, going idle or being deactivated or deleted disconnects the user.
+// An open connection counts as activity, so it keeps its session from idling.
+func (h *WebSocketHandler) sessionCheck(userID uint, sessionID string) websocket.CheckFunc {
+	return func() error {
+		// Checks run on ping ticks long after the handshake request has ended
+		ctx := context.Background()
+
+		if err := h.authService.ValidateSession(ctx, userID, sessionID); err != nil {
+			return err
+		}
+
+		user, err := h.userService.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !user.IsActive {
+			return services.ErrUserInactive
+		}
+		return nil
+	}
+}
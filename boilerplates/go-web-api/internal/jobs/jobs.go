@@ -0,0 +1,107 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/yourusername/go-web-api/internal/email"
+	"github.com/yourusername/go-web-api/internal/services"
+	"github.com/yourusername/go-web-api/internal/websocket"
+	"github.com/yourusername/go-web-api/internal/worker"
+
+	"github.com/rs/zerolog"
+)
+
+// Job types
+const (
+	TypeCleanupSessions       = "auth:cleanup_sessions"
+	TypeCleanupPasswordResets = "auth:cleanup_password_resets"
+	TypeSendEmail             = "email:send"
+	TypeNotify                = "notification:send"
+)
+
+// CleanupSessions returns a job handler that purges expired sessions and refresh tokens
+func CleanupSessions(authService services.AuthService, logger *zerolog.Logger) worker.HandlerFunc {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		removed, err := authService.PurgeExpired(ctx)
+		if err != nil {
+			return err
+		}
+
+		logger.Info().Int64("removed", removed).Msg("Purged expired sessions")
+		return nil
+	}
+}
+
+// CleanupPasswordResets returns a job handler that purges expired password reset tokens
+func CleanupPasswordResets(passwordService services.PasswordService, logger *zerolog.Logger) worker.HandlerFunc {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		removed, err := passwordService.PurgeExpired(ctx)
+		if err != nil {
+			return err
+		}
+
+		logger.Info().Int64("removed", removed).Msg("Purged expired password reset tokens")
+		return nil
+	}
+}
+
+// SendEmail returns a job handler that delivers an email.Message payload
+func SendEmail(sender email.Sender) worker.HandlerFunc {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		var msg email.Message
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			return fmt.Errorf("failed to decode email: %w", err)
+		}
+
+		return sender.Send(ctx, &msg)
+	}
+}
+
+// Notification is the payload of a TypeNotify job
+type Notification struct {
+	UserID uint            `json:"user_id"`
+	Type   string          `json:"type"`
+	Data   json.RawMessage `json:"data,omitempty"`
+}
+
+// SendNotification returns a job handler that pushes a Notification payload to
+// the WebSocket connections of its user
+func SendNotification(hub *websocket.Hub) worker.HandlerFunc {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		var n Notification
+		if err := json.Unmarshal(payload, &n); err != nil {
+			return fmt.Errorf("failed to decode notification: %w", err)
+		}
+
+		return hub.Broadcast(websocket.UserRoom(n.UserID), n.Type, n.Data)
+	}
+}
+
+// BroadcastJobFinished returns a worker OnFinish callback that reports finished
+// jobs to connected admins. Job payloads are not part of their JSON encoding.
+func BroadcastJobFinished(hub *websocket.Hub, logger *zerolog.Logger) func(worker.Job) {
+	return func(job worker.Job) {
+		if err := hub.Broadcast(websocket.RoomAdmin, "job."+string(job.Status), job); err != nil {
+			logger.Error().Err(err).Str("job_id", job.ID).Msg("Failed to broadcast job status")
+		}
+	}
+}
+
+// EmailQueue queues emails for delivery by the background worker
+type EmailQueue struct {
+	worker *worker.Worker
+}
+
+// NewEmailQueue creates a new email queue. The worker must have the
+// TypeSendEmail handler registered.
+func NewEmailQueue(w *worker.Worker) *EmailQueue {
+	return &EmailQueue{worker: w}
+}
+
+// Enqueue queues an email for delivery
+func (q *EmailQueue) Enqueue(msg *email.Message) error {
+	_, err := q.worker.Enqueue(TypeSendEmail, msg)
+	return err
+}
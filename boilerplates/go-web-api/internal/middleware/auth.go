@@ -1,18 +1,29 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/yourusername/go-web-api/internal/audit"
 	"github.com/yourusername/go-web-api/internal/config"
+	"github.com/yourusername/go-web-api/internal/services"
 	"github.com/yourusername/go-web-api/internal/utils"
 	"github.com/yourusername/go-web-api/pkg/response"
 
 	"github.com/gin-gonic/gin"
 )
 
-// Auth returns a gin middleware for JWT authentication
-func Auth(cfg *config.Config) gin.HandlerFunc {
+// authError describes why a request could not be authenticated
+type authError struct {
+	status  int
+	message string
+	err     error
+}
+
+// Auth returns a gin middleware for JWT authentication backed by server-side sessions
+func Auth(cfg *config.Config, authService services.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -22,28 +33,105 @@ func Auth(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
-		// Check Bearer token format
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			response.Error(c, http.StatusUnauthorized, "Invalid authorization header format", nil)
+		claims, authErr := authenticate(c.Request.Context(), cfg, authService, authHeader)
+		if authErr != nil {
+			response.Error(c, authErr.status, authErr.message, authErr.err)
 			c.Abort()
 			return
 		}
 
-		token := parts[1]
+		setClaims(c, claims)
+
+		c.Next()
+	}
+}
+
+// OptionalAuth returns a gin middleware that lets requests without an
+// Authorization header through anonymously. Requests that carry one must
+// authenticate like with Auth, so an expired or invalid token is rejected
+// rather than treated as anonymous. Handlers see a user_id of 0 for anonymous
+// requests.
+func OptionalAuth(cfg *config.Config, authService services.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+			claims, authErr := authenticate(c.Request.Context(), cfg, authService, authHeader)
+			if authErr != nil {
+				response.Error(c, authErr.status, authErr.message, authErr.err)
+				c.Abort()
+				return
+			}
+			setClaims(c, claims)
+		}
+
+		c.Next()
+	}
+}
+
+// WebSocketAuth returns a gin middleware authenticating WebSocket handshakes.
+// Browsers cannot set headers on WebSocket requests, so the access token may
+// also be passed in the token query parameter.
+func WebSocketAuth(cfg *config.Config, authService services.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" && c.Query("token") != "" {
+			authHeader = "Bearer " + c.Query("token")
+		}
+		if authHeader == "" {
+			response.Error(c, http.StatusUnauthorized, "Access token required", nil)
+			c.Abort()
+			return
+		}
 
-		// Validate token
-		claims, err := utils.ValidateToken(token, cfg.JWTSecret)
-		if err != nil {
-			response.Error(c, http.StatusUnauthorized, "Invalid or expired token", err)
+		claims, authErr := authenticate(c.Request.Context(), cfg, authService, authHeader)
+		if authErr != nil {
+			response.Error(c, authErr.status, authErr.message, authErr.err)
 			c.Abort()
 			return
 		}
 
-		// Set user ID in context
-		c.Set("user_id", claims.UserID)
-		c.Set("email", claims.Email)
+		setClaims(c, claims)
 
 		c.Next()
 	}
 }
+
+// authenticate validates a bearer token and its server-side session
+func authenticate(ctx context.Context, cfg *config.Config, authService services.AuthService, authHeader string) (*utils.JWTClaims, *authError) {
+	// Check Bearer token format
+	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, &authError{http.StatusUnauthorized, "Invalid authorization header format", nil}
+	}
+
+	token := parts[1]
+
+	// Validate token
+	claims, err := utils.ValidateToken(token, cfg.JWTSecret)
+	if err != nil {
+		// Let clients distinguish an expired token, which they can refresh
+		if errors.Is(err, utils.ErrExpiredToken) {
+			return nil, &authError{http.StatusUnauthorized, "Access token expired", err}
+		}
+		return nil, &authError{http.StatusUnauthorized, "Invalid token", err}
+	}
+
+	// Check that the session has not been revoked or gone idle
+	if err := authService.ValidateSession(ctx, claims.UserID, claims.SessionID); err != nil {
+		if errors.Is(err, services.ErrSessionNotFound) || errors.Is(err, services.ErrSessionExpired) {
+			return nil, &authError{http.StatusUnauthorized, "Session expired or revoked", err}
+		}
+		return nil, &authError{http.StatusInternalServerError, "Failed to validate session", err}
+	}
+
+	return claims, nil
+}
+
+// setClaims stores the authenticated user in the gin context and records it
+// as the actor of the request for the audit columns
+func setClaims(c *gin.Context, claims *utils.JWTClaims) {
+	c.Set("user_id", claims.UserID)
+	c.Set("email", claims.Email)
+	c.Set("role", claims.Role)
+	c.Set("session_id", claims.SessionID)
+	c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), claims.UserID))
+}
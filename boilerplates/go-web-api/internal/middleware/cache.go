@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/go-web-api/internal/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cachedResponse represents a cached HTTP response
+type cachedResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// bodyRecorder captures the response body while writing it to the client
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// CacheResponse returns a gin middleware that caches successful GET responses
+// by request URI. Services invalidate a namespace with cache.ResponsePrefix.
+func CacheResponse(store cache.Cache, namespace string, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		key := cache.ResponseKey(namespace, c.Request.URL.RequestURI())
+
+		var cached cachedResponse
+		if err := store.Get(c.Request.Context(), key, &cached); err == nil {
+			c.Header("X-Cache", "HIT")
+			c.Data(cached.Status, cached.ContentType, cached.Body)
+			c.Abort()
+			return
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Header("X-Cache", "MISS")
+
+		c.Next()
+
+		// Errors are rendered later by ErrorHandler, so only cache written successes
+		if len(c.Errors) == 0 && recorder.Written() && recorder.Status() == http.StatusOK {
+			_ = store.Set(c.Request.Context(), key, cachedResponse{
+				Status:      recorder.Status(),
+				ContentType: recorder.Header().Get("Content-Type"),
+				Body:        recorder.body.Bytes(),
+			}, ttl)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/yourusername/go-web-api/internal/apperror"
+	"github.com/yourusername/go-web-api/internal/services"
+	"github.com/yourusername/go-web-api/internal/worker"
+	"github.com/yourusername/go-web-api/pkg/query"
+	"github.com/yourusername/go-web-api/pkg/response"
+	"github.com/yourusername/go-web-api/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// errorMapping maps a service error to its HTTP representation
+type errorMapping struct {
+	err     error
+	status  int
+	code    string
+	message string
+}
+
+// errorMappings lists the service errors known to the API. Errors not listed
+// here are reported as internal server errors without exposing details.
+var errorMappings = []errorMapping{
+	{services.ErrUserNotFound, http.StatusNotFound, "USER_NOT_FOUND", "User not found"},
+	{services.ErrEmailAlreadyExists, http.StatusConflict, "EMAIL_ALREADY_EXISTS", "Email already exists"},
+	{services.ErrUsernameAlreadyExists, http.StatusConflict, "USERNAME_ALREADY_EXISTS", "Username already exists"},
+	{services.ErrUserVersionConflict, http.StatusConflict, "VERSION_CONFLICT", "User was modified by another request"},
+	{services.ErrInvalidCredentials, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid email or password"},
+	{services.ErrUserInactive, http.StatusForbidden, "USER_INACTIVE", "User is inactive"},
+	{services.ErrSessionNotFound, http.StatusNotFound, "SESSION_NOT_FOUND", "Session not found"},
+	{services.ErrSessionExpired, http.StatusUnauthorized, "SESSION_EXPIRED", "Session expired or revoked"},
+	{services.ErrInvalidRefreshToken, http.StatusUnauthorized, "INVALID_REFRESH_TOKEN", "Invalid or expired refresh token"},
+	{services.ErrInvalidResetToken, http.StatusBadRequest, "INVALID_RESET_TOKEN", "Invalid or expired password reset token"},
+	{services.ErrUploadNotFound, http.StatusNotFound, "UPLOAD_NOT_FOUND", "Upload not found"},
+	{services.ErrFileTooLarge, http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", "File is too large"},
+	{services.ErrFileTypeNotAllowed, http.StatusUnsupportedMediaType, "FILE_TYPE_NOT_ALLOWED", "File type is not allowed"},
+	{services.ErrInvalidDownloadURL, http.StatusForbidden, "INVALID_DOWNLOAD_URL", "Invalid or expired download URL"},
+	{worker.ErrJobNotFound, http.StatusNotFound, "JOB_NOT_FOUND", "Job not found"},
+	{query.ErrInvalidPage, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters"},
+	{query.ErrPageTooLarge, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters"},
+	{query.ErrInvalidLimit, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters"},
+	{query.ErrInvalidSortField, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters"},
+	{query.ErrInvalidFilterField, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters"},
+	{query.ErrInvalidFilterValue, http.StatusBadRequest, "INVALID_QUERY", "Invalid query parameters"},
+}
+
+// ErrorHandler returns a gin middleware that renders errors attached with
+// c.Error as the standard error envelope
+func ErrorHandler(logger *zerolog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+
+		// Validation errors from request binding
+		if fields, ok := validation.FieldErrors(err); ok {
+			response.Fail(c, http.StatusBadRequest, "VALIDATION_FAILED", "Validation failed", "One or more fields are invalid", fields)
+			return
+		}
+
+		// Malformed request bodies
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			response.Fail(c, http.StatusBadRequest, "INVALID_BODY", "Invalid request body", err.Error(), nil)
+			return
+		}
+
+		// Errors raised by handlers
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) {
+			response.Fail(c, appErr.Status, appErr.Code, appErr.Message, err.Error(), nil)
+			return
+		}
+
+		// Known service errors
+		for _, m := range errorMappings {
+			if errors.Is(err, m.err) {
+				response.Fail(c, m.status, m.code, m.message, err.Error(), nil)
+				return
+			}
+		}
+
+		logger.Error().
+			Err(err).
+			Str("method", c.Request.Method).
+			Str("path", c.Request.URL.Path).
+			Msg("Unhandled error")
+
+		response.Fail(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error", "An unexpected error occurred", nil)
+	}
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/yourusername/go-web-api/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HSTS returns a gin middleware that sets the Strict-Transport-Security header
+func HSTS(cfg *config.Config) gin.HandlerFunc {
+	header := fmt.Sprintf("max-age=%d; includeSubDomains", cfg.HSTSMaxAge)
+
+	return func(c *gin.Context) {
+		// Only advertise HSTS when TLS is terminated by this server
+		if cfg.TLSEnabled && cfg.HSTSMaxAge > 0 {
+			c.Writer.Header().Set("Strict-Transport-Security", header)
+		}
+
+		c.Next()
+	}
+}
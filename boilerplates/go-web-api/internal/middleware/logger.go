@@ -1,10 +1,12 @@
 package middleware
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Logger returns a gin middleware for logging requests
@@ -12,7 +14,7 @@ func Logger(logger *zerolog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
-		query := c.Request.URL.RawQuery
+		query := redactQuery(c.Request.URL.RawQuery)
 
 		// Process request
 		c.Next()
@@ -31,6 +33,11 @@ func Logger(logger *zerolog.Logger) gin.HandlerFunc {
 			logEvent = logger.Warn()
 		}
 
+		// Correlate the log line with its trace when tracing is enabled
+		if spanCtx := trace.SpanContextFromContext(c.Request.Context()); spanCtx.HasTraceID() {
+			logEvent = logEvent.Str("trace_id", spanCtx.TraceID().String())
+		}
+
 		logEvent.
 			Str("method", c.Request.Method).
 			Str("path", path).
@@ -42,3 +49,14 @@ func Logger(logger *zerolog.Logger) gin.HandlerFunc {
 			Msg("HTTP Request")
 	}
 }
+
+// redactQuery hides access tokens passed as query parameters, as done by
+// WebSocket clients, from the logs
+func redactQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil || !values.Has("token") {
+		return rawQuery
+	}
+	values.Set("token", "REDACTED")
+	return values.Encode()
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/yourusername/go-web-api/internal/telemetry"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics returns a gin middleware that records request count, duration and
+// errors. Requests are labeled by route pattern to keep cardinality bounded.
+func Metrics(m *telemetry.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		m.RequestsInFlight.Inc()
+
+		c.Next()
+
+		m.RequestsInFlight.Dec()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		method := c.Request.Method
+		status := c.Writer.Status()
+		statusLabel := strconv.Itoa(status)
+
+		m.RequestsTotal.WithLabelValues(method, route, statusLabel).Inc()
+		m.RequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+		if status >= 500 {
+			m.RequestErrors.WithLabelValues(method, route, statusLabel).Inc()
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/yourusername/go-web-api/pkg/response"
@@ -21,7 +20,8 @@ func Recovery(logger *zerolog.Logger) gin.HandlerFunc {
 					Str("method", c.Request.Method).
 					Msg("Panic recovered")
 
-				response.Error(c, http.StatusInternalServerError, "Internal server error", fmt.Errorf("%v", err))
+				// Do not expose the panic value to clients
+				response.Fail(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error", "An unexpected error occurred", nil)
 				c.Abort()
 			}
 		}()
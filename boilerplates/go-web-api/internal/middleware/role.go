@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/yourusername/go-web-api/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole returns a gin middleware that only allows users with one of the given roles.
+// It must be used after Auth.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		response.Error(c, http.StatusForbidden, "Insufficient permissions", nil)
+		c.Abort()
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/yourusername/go-web-api/internal/config"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// Tracing returns a gin middleware that starts an OpenTelemetry span for each
+// request. Health checks and metric scrapes are not traced.
+func Tracing(cfg *config.Config) gin.HandlerFunc {
+	return otelgin.Middleware(cfg.AppName, otelgin.WithFilter(func(r *http.Request) bool {
+		return r.URL.Path != "/health" && r.URL.Path != cfg.MetricsPath
+	}))
+}
@@ -0,0 +1,22 @@
+package models
+
+// LoginRequest represents the request body for logging in
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RefreshRequest represents the request body for refreshing an access token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LoginResponse represents the response for a successful login or token refresh
+type LoginResponse struct {
+	AccessToken      string        `json:"access_token"`
+	RefreshToken     string        `json:"refresh_token"`
+	TokenType        string        `json:"token_type"`
+	ExpiresIn        int64         `json:"expires_in"`
+	RefreshExpiresIn int64         `json:"refresh_expires_in"`
+	User             *UserResponse `json:"user"`
+}
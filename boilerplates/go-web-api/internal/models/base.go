@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Model is the base model for entities with audit columns, soft deletes and
+// optimistic locking. Embed it instead of declaring ID and timestamps.
+// CreatedBy and UpdatedBy are filled by the audit callbacks from the actor in
+// the query context.
+type Model struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete
+	CreatedBy *uint          `json:"created_by"`
+	UpdatedBy *uint          `json:"updated_by"`
+	Version   uint           `json:"version" gorm:"not null;default:1"` // Optimistic locking
+}
+
+// BeforeCreate starts the version of new records at 1
+func (m *Model) BeforeCreate(tx *gorm.DB) error {
+	if m.Version == 0 {
+		m.Version = 1
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// RefreshToken represents a refresh token issued for a session. Only the
+// SHA-256 hash of the token is stored.
+type RefreshToken struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	SessionID string     `json:"session_id" gorm:"size:64;index;not null"`
+	UserID    uint       `json:"user_id" gorm:"index;not null"`
+	TokenHash string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at" gorm:"index"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// Session represents an authenticated login session of a user
+type Session struct {
+	ID             string     `json:"id" gorm:"primaryKey;size:64"`
+	UserID         uint       `json:"user_id" gorm:"index;not null"`
+	UserAgent      string     `json:"user_agent"`
+	IPAddress      string     `json:"ip_address"`
+	LastActivityAt time.Time  `json:"last_activity_at"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"index"`
+	RevokedAt      *time.Time `json:"revoked_at" gorm:"index"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// SessionResponse represents the response for a session
+type SessionResponse struct {
+	ID             string    `json:"id"`
+	UserAgent      string    `json:"user_agent"`
+	IPAddress      string    `json:"ip_address"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	Current        bool      `json:"current"`
+}
+
+// ToResponse converts a Session model to SessionResponse
+func (s *Session) ToResponse(currentSessionID string) *SessionResponse {
+	return &SessionResponse{
+		ID:             s.ID,
+		UserAgent:      s.UserAgent,
+		IPAddress:      s.IPAddress,
+		LastActivityAt: s.LastActivityAt,
+		ExpiresAt:      s.ExpiresAt,
+		CreatedAt:      s.CreatedAt,
+		Current:        s.ID == currentSessionID,
+	}
+}
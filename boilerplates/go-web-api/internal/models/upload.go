@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Upload represents a file uploaded by a user
+type Upload struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	UserID      uint           `json:"user_id" gorm:"index;not null"`
+	Key         string         `json:"-" gorm:"uniqueIndex;not null"`
+	Filename    string         `json:"filename" gorm:"not null"`
+	ContentType string         `json:"content_type"`
+	Size        int64          `json:"size"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete
+}
+
+// UploadResponse represents the response for an upload
+type UploadResponse struct {
+	ID          uint      `json:"id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	URL         string    `json:"url,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ToResponse converts an Upload model to UploadResponse with an optional download URL
+func (u *Upload) ToResponse(url string) *UploadResponse {
+	return &UploadResponse{
+		ID:          u.ID,
+		Filename:    u.Filename,
+		ContentType: u.ContentType,
+		Size:        u.Size,
+		URL:         url,
+		CreatedAt:   u.CreatedAt,
+	}
+}
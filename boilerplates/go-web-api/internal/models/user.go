@@ -2,29 +2,31 @@ package models
 
 import (
 	"time"
+)
 
-	"gorm.io/gorm"
+// User roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
 )
 
 // User represents a user in the system
 type User struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Email     string         `json:"email" gorm:"uniqueIndex;not null"`
-	Username  string         `json:"username" gorm:"uniqueIndex;not null"`
-	Password  string         `json:"-" gorm:"not null"` // Never expose password in JSON
-	FirstName string         `json:"first_name"`
-	LastName  string         `json:"last_name"`
-	IsActive  bool           `json:"is_active" gorm:"default:true"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"` // Soft delete
+	Model
+	Email     string `json:"email" gorm:"uniqueIndex:idx_users_email_active,where:deleted_at IS NULL;not null"`       // Unique among non-deleted users
+	Username  string `json:"username" gorm:"uniqueIndex:idx_users_username_active,where:deleted_at IS NULL;not null"` // Unique among non-deleted users
+	Password  string `json:"-" gorm:"not null"`                                                                       // Never expose password in JSON
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Role      string `json:"role" gorm:"not null;default:user"`
+	IsActive  bool   `json:"is_active" gorm:"default:true"`
 }
 
 // UserCreateRequest represents the request body for creating a user
 type UserCreateRequest struct {
 	Email     string `json:"email" binding:"required,email"`
 	Username  string `json:"username" binding:"required,min=3,max=50"`
-	Password  string `json:"password" binding:"required,min=8"`
+	Password  string `json:"password" binding:"required,min=8,max=72"`
 	FirstName string `json:"first_name" binding:"omitempty,max=100"`
 	LastName  string `json:"last_name" binding:"omitempty,max=100"`
 }
@@ -36,6 +38,7 @@ type UserUpdateRequest struct {
 	FirstName string `json:"first_name" binding:"omitempty,max=100"`
 	LastName  string `json:"last_name" binding:"omitempty,max=100"`
 	IsActive  *bool  `json:"is_active" binding:"omitempty"`
+	Version   *uint  `json:"version" binding:"omitempty"` // Version the change is based on
 }
 
 // UserResponse represents the response for a user (without sensitive data)
@@ -45,7 +48,11 @@ type UserResponse struct {
 	Username  string    `json:"username"`
 	FirstName string    `json:"first_name"`
 	LastName  string    `json:"last_name"`
+	Role      string    `json:"role"`
 	IsActive  bool      `json:"is_active"`
+	Version   uint      `json:"version"`
+	CreatedBy *uint     `json:"created_by"`
+	UpdatedBy *uint     `json:"updated_by"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -58,7 +65,11 @@ func (u *User) ToResponse() *UserResponse {
 		Username:  u.Username,
 		FirstName: u.FirstName,
 		LastName:  u.LastName,
+		Role:      u.Role,
 		IsActive:  u.IsActive,
+		Version:   u.Version,
+		CreatedBy: u.CreatedBy,
+		UpdatedBy: u.UpdatedBy,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
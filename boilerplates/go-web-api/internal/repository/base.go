@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/yourusername/go-web-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict is returned when a record was changed by someone else
+// since it was read
+var ErrVersionConflict = errors.New("record was modified concurrently")
+
+// updateVersioned saves all fields of a record embedding models.Model, but
+// only if its version is unchanged in the database, and increments the version
+func updateVersioned(db *gorm.DB, value interface{}, base *models.Model) error {
+	expected := base.Version
+	base.Version++
+
+	result := db.Model(value).
+		Where("version = ?", expected).
+		Select("*").
+		Omit("created_at", "created_by", "deleted_at").
+		Updates(value)
+	if result.Error != nil {
+		base.Version = expected
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		base.Version = expected
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// softDelete records who deleted a record embedding models.Model and soft
+// deletes it. The audit callbacks set updated_by from the context of db.
+func softDelete(db *gorm.DB, value interface{}, id uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(value).
+			Where("id = ?", id).
+			Update("version", gorm.Expr("version + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Delete(value, id).Error
+	})
+}
+
+// restore undoes the soft delete of a record embedding models.Model
+func restore(db *gorm.DB, value interface{}, id uint) error {
+	result := db.Model(value).
+		Scopes(OnlyDeleted).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
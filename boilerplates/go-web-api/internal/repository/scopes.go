@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"github.com/yourusername/go-web-api/pkg/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Paginate returns a scope applying the limit and offset of the list parameters
+func Paginate(params *query.Params) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Limit(params.Limit).Offset(params.Offset())
+	}
+}
+
+// Sort returns a scope ordering by the sort fields of the list parameters.
+// Unless already sorted by id, rows are finally ordered by id, in the
+// direction of the last sort field, so rows with equal sort values keep a
+// stable order across pages.
+func Sort(params *query.Params) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		sortedByID := false
+		desc := false
+		for _, field := range params.Sort {
+			db = db.Order(clause.OrderByColumn{
+				Column: clause.Column{Name: field.Column},
+				Desc:   field.Desc,
+			})
+			sortedByID = sortedByID || field.Column == "id"
+			desc = field.Desc
+		}
+		if sortedByID {
+			return db
+		}
+		return db.Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "id"},
+			Desc:   desc,
+		})
+	}
+}
+
+// Filter returns a scope applying the equality filters of the list parameters
+func Filter(params *query.Params) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, filter := range params.Filters {
+			db = db.Where(clause.Eq{
+				Column: clause.Column{Name: filter.Column},
+				Value:  filter.Value,
+			})
+		}
+		return db
+	}
+}
+
+// WithDeleted returns a scope including soft deleted records
+func WithDeleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// OnlyDeleted returns a scope selecting only soft deleted records
+func OnlyDeleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Where("deleted_at IS NOT NULL")
+}
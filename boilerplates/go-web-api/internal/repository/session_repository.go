@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/go-web-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// SessionRepository handles session data operations
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) error
+	GetByID(ctx context.Context, id string) (*models.Session, error)
+	ListActiveByUser(ctx context.Context, userID uint, now, activeSince time.Time) ([]models.Session, error)
+	Touch(ctx context.Context, id string, at time.Time) error
+	Extend(ctx context.Context, id string, at, expiresAt time.Time) error
+	Revoke(ctx context.Context, id string, at time.Time) error
+	RevokeByUser(ctx context.Context, userID uint, at time.Time) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// Create creates a new session
+func (r *sessionRepository) Create(ctx context.Context, session *models.Session) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+// GetByID retrieves a session by ID
+func (r *sessionRepository) GetByID(ctx context.Context, id string) (*models.Session, error) {
+	var session models.Session
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListActiveByUser retrieves the non-revoked, non-expired sessions of a user
+// with activity after activeSince, oldest first
+func (r *sessionRepository) ListActiveByUser(ctx context.Context, userID uint, now, activeSince time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ? AND last_activity_at > ?", userID, now, activeSince).
+		Order("created_at ASC, id ASC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// Touch updates the last activity time of a session
+func (r *sessionRepository) Touch(ctx context.Context, id string, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("id = ?", id).
+		Update("last_activity_at", at).Error
+}
+
+// Extend records activity on a session and moves its expiry forward
+func (r *sessionRepository) Extend(ctx context.Context, id string, at, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_activity_at": at,
+			"expires_at":       expiresAt,
+		}).Error
+}
+
+// Revoke marks a session as revoked
+func (r *sessionRepository) Revoke(ctx context.Context, id string, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+// RevokeByUser marks all sessions of a user as revoked
+func (r *sessionRepository) RevokeByUser(ctx context.Context, userID uint, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
+
+// DeleteExpired permanently deletes sessions that expired before the given time
+func (r *sessionRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&models.Session{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/go-web-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// UploadRepository handles upload data operations
+type UploadRepository interface {
+	Create(ctx context.Context, upload *models.Upload) error
+	GetByID(ctx context.Context, id uint) (*models.Upload, error)
+	GetByKey(ctx context.Context, key string) (*models.Upload, error)
+	Delete(ctx context.Context, id uint) error
+}
+
+type uploadRepository struct {
+	db *gorm.DB
+}
+
+// NewUploadRepository creates a new upload repository
+func NewUploadRepository(db *gorm.DB) UploadRepository {
+	return &uploadRepository{db: db}
+}
+
+// Create creates a new upload
+func (r *uploadRepository) Create(ctx context.Context, upload *models.Upload) error {
+	return r.db.WithContext(ctx).Create(upload).Error
+}
+
+// GetByID retrieves an upload by ID
+func (r *uploadRepository) GetByID(ctx context.Context, id uint) (*models.Upload, error) {
+	var upload models.Upload
+	if err := r.db.WithContext(ctx).First(&upload, id).Error; err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// GetByKey retrieves an upload by its storage key
+func (r *uploadRepository) GetByKey(ctx context.Context, key string) (*models.Upload, error) {
+	var upload models.Upload
+	if err := r.db.WithContext(ctx).Where("key = ?", key).First(&upload).Error; err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// Delete soft deletes an upload
+func (r *uploadRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.Upload{}, id).Error
+}
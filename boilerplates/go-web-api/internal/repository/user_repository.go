@@ -1,19 +1,25 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/yourusername/go-web-api/internal/models"
+	"github.com/yourusername/go-web-api/pkg/query"
 	"gorm.io/gorm"
 )
 
 // UserRepository handles user data operations
 type UserRepository interface {
-	Create(user *models.User) error
-	GetByID(id uint) (*models.User, error)
-	GetByEmail(email string) (*models.User, error)
-	GetByUsername(username string) (*models.User, error)
-	List(limit, offset int) ([]models.User, int64, error)
-	Update(user *models.User) error
-	Delete(id uint) error
+	Create(ctx context.Context, user *models.User) error
+	GetByID(ctx context.Context, id uint) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetByUsername(ctx context.Context, username string) (*models.User, error)
+	GetDeletedByID(ctx context.Context, id uint) (*models.User, error)
+	List(ctx context.Context, params *query.Params) ([]models.User, int64, error)
+	ListDeleted(ctx context.Context, params *query.Params) ([]models.User, int64, error)
+	Update(ctx context.Context, user *models.User) error
+	Delete(ctx context.Context, id uint) error
+	Restore(ctx context.Context, id uint) error
 }
 
 type userRepository struct {
@@ -25,62 +31,93 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db: db}
 }
 
-// Create creates a new user
-func (r *userRepository) Create(user *models.User) error {
-	return r.db.Create(user).Error
+// Create creates a new user on behalf of the actor in ctx
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
 }
 
 // GetByID retrieves a user by ID
-func (r *userRepository) GetByID(id uint) (*models.User, error) {
+func (r *userRepository) GetByID(ctx context.Context, id uint) (*models.User, error) {
 	var user models.User
-	if err := r.db.First(&user, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetDeletedByID retrieves a soft deleted user by ID
+func (r *userRepository) GetDeletedByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Scopes(OnlyDeleted).First(&user, id).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
 // GetByEmail retrieves a user by email
-func (r *userRepository) GetByEmail(email string) (*models.User, error) {
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
 // GetByUsername retrieves a user by username
-func (r *userRepository) GetByUsername(username string) (*models.User, error) {
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
 	var user models.User
-	if err := r.db.Where("username = ?", username).First(&user).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-// List retrieves a paginated list of users
-func (r *userRepository) List(limit, offset int) ([]models.User, int64, error) {
+// List retrieves a filtered, sorted and paginated list of users
+func (r *userRepository) List(ctx context.Context, params *query.Params) ([]models.User, int64, error) {
 	var users []models.User
 	var total int64
 
 	// Get total count
-	if err := r.db.Model(&models.User{}).Count(&total).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&models.User{}).Scopes(Filter(params)).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated results
-	if err := r.db.Limit(limit).Offset(offset).Find(&users).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(Filter(params), Sort(params), Paginate(params)).Find(&users).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return users, total, nil
 }
 
-// Update updates a user
-func (r *userRepository) Update(user *models.User) error {
-	return r.db.Save(user).Error
+// ListDeleted retrieves a filtered, sorted and paginated list of soft deleted users
+func (r *userRepository) ListDeleted(ctx context.Context, params *query.Params) ([]models.User, int64, error) {
+	var users []models.User
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&models.User{}).Scopes(OnlyDeleted, Filter(params)).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.db.WithContext(ctx).Scopes(OnlyDeleted, Filter(params), Sort(params), Paginate(params)).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// Update updates a user on behalf of the actor in ctx, failing with
+// ErrVersionConflict if it was changed since it was read
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	return updateVersioned(r.db.WithContext(ctx), user, &user.Model)
+}
+
+// Delete soft deletes a user on behalf of the actor in ctx
+func (r *userRepository) Delete(ctx context.Context, id uint) error {
+	return softDelete(r.db.WithContext(ctx), &models.User{}, id)
 }
 
-// Delete soft deletes a user
-func (r *userRepository) Delete(id uint) error {
-	return r.db.Delete(&models.User{}, id).Error
+// Restore restores a soft deleted user on behalf of the actor in ctx
+func (r *userRepository) Restore(ctx context.Context, id uint) error {
+	return restore(r.db.WithContext(ctx), &models.User{}, id)
 }
@@ -0,0 +1,152 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/yourusername/go-web-api/internal/config"
+	"github.com/yourusername/go-web-api/internal/models"
+	"github.com/yourusername/go-web-api/internal/utils"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownSet    = errors.New("unknown seed set")
+	ErrSetNotAllowed = errors.New("seed set not allowed in this environment")
+)
+
+// Set is a named group of records seeded together in one transaction. Sets
+// must be idempotent: records that already exist are left untouched.
+type Set struct {
+	Name         string
+	Description  string
+	Environments []string
+	Run          func(tx *gorm.DB, cfg *config.Config, logger *zerolog.Logger) error
+}
+
+// Sets lists the available seed sets in the order they run
+var Sets = []Set{
+	{
+		Name:         "admin",
+		Description:  "Initial admin user from SEED_ADMIN_*",
+		Environments: []string{"development", "test", "staging", "production"},
+		Run:          seedAdmin,
+	},
+	{
+		Name:         "demo",
+		Description:  "Demo users for local development",
+		Environments: []string{"development"},
+		Run:          seedDemo,
+	},
+	{
+		Name:         "fixtures",
+		Description:  "Deterministic users for automated tests",
+		Environments: []string{"test"},
+		Run:          seedFixtures,
+	},
+}
+
+// ForEnvironment returns the names of the sets that run in an environment
+func ForEnvironment(env string) []string {
+	var names []string
+	for _, set := range Sets {
+		if set.Allowed(env) {
+			names = append(names, set.Name)
+		}
+	}
+	return names
+}
+
+// Allowed reports whether a set is meant to run in an environment
+func (s Set) Allowed(env string) bool {
+	for _, e := range s.Environments {
+		if e == env {
+			return true
+		}
+	}
+	return false
+}
+
+// Run runs the named seed sets, each in its own transaction, in the order
+// they are declared in Sets. Sets not meant for the configured APP_ENV, which
+// is the environment of the database being seeded, are rejected unless force
+// is set.
+func Run(db *gorm.DB, cfg *config.Config, logger *zerolog.Logger, names []string, force bool) error {
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		selected[name] = true
+	}
+
+	var sets []Set
+	for _, set := range Sets {
+		if selected[set.Name] {
+			sets = append(sets, set)
+			delete(selected, set.Name)
+		}
+	}
+
+	if len(selected) > 0 {
+		unknown := make([]string, 0, len(selected))
+		for name := range selected {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: %v", ErrUnknownSet, unknown)
+	}
+
+	for _, set := range sets {
+		if !force && !set.Allowed(cfg.AppEnv) {
+			return fmt.Errorf("%w: %s in %s", ErrSetNotAllowed, set.Name, cfg.AppEnv)
+		}
+	}
+
+	for _, set := range sets {
+		logger.Info().Str("set", set.Name).Msg("Seeding")
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			return set.Run(tx, cfg, logger)
+		}); err != nil {
+			return fmt.Errorf("failed to seed %s: %w", set.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ensureUser creates a user unless one with the same email or username
+// exists, including soft deleted users. It reports whether the user was created.
+func ensureUser(tx *gorm.DB, user *models.User, password string) (bool, error) {
+	var count int64
+	err := tx.Model(&models.User{}).
+		Unscoped().
+		Where("email = ? OR username = ?", user.Email, user.Username).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check user %s: %w", user.Email, err)
+	}
+	if count > 0 {
+		return false, nil
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return false, fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.Password = hashedPassword
+
+	if err := tx.Create(user).Error; err != nil {
+		return false, fmt.Errorf("failed to create user %s: %w", user.Email, err)
+	}
+	return true, nil
+}
+
+// logUser logs the outcome of ensureUser
+func logUser(logger *zerolog.Logger, user *models.User, created bool) {
+	if created {
+		logger.Info().Str("email", user.Email).Msg("Created user")
+	} else {
+		logger.Debug().Str("email", user.Email).Msg("User already exists")
+	}
+}
@@ -0,0 +1,110 @@
+package seed
+
+import (
+	"fmt"
+
+	"github.com/yourusername/go-web-api/internal/config"
+	"github.com/yourusername/go-web-api/internal/models"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+// DemoPassword is the password of the demo users
+const DemoPassword = "demo-password"
+
+// FixturePassword is the password of the test fixture users
+const FixturePassword = "fixture-password"
+
+// seedAdmin creates the initial admin user. It is skipped unless a password
+// is configured, so no environment ends up with a well-known admin password.
+func seedAdmin(tx *gorm.DB, cfg *config.Config, logger *zerolog.Logger) error {
+	if cfg.SeedAdminPassword == "" {
+		logger.Warn().Msg("SEED_ADMIN_PASSWORD is not set, skipping admin user")
+		return nil
+	}
+
+	user := &models.User{
+		Email:     cfg.SeedAdminEmail,
+		Username:  cfg.SeedAdminUsername,
+		FirstName: "Admin",
+		Role:      models.RoleAdmin,
+		IsActive:  true,
+	}
+
+	created, err := ensureUser(tx, user, cfg.SeedAdminPassword)
+	if err != nil {
+		return err
+	}
+	logUser(logger, user, created)
+	return nil
+}
+
+// seedDemo creates a handful of users to explore the API with
+func seedDemo(tx *gorm.DB, cfg *config.Config, logger *zerolog.Logger) error {
+	names := []struct{ first, last string }{
+		{"Alice", "Anderson"},
+		{"Bob", "Brown"},
+		{"Carol", "Clark"},
+		{"Dave", "Davis"},
+		{"Eve", "Evans"},
+	}
+
+	for i, name := range names {
+		user := &models.User{
+			Email:     fmt.Sprintf("demo%d@example.com", i+1),
+			Username:  fmt.Sprintf("demo%d", i+1),
+			FirstName: name.first,
+			LastName:  name.last,
+			Role:      models.RoleUser,
+			IsActive:  true,
+		}
+
+		created, err := ensureUser(tx, user, DemoPassword)
+		if err != nil {
+			return err
+		}
+		logUser(logger, user, created)
+	}
+
+	return nil
+}
+
+// seedFixtures creates users in every state the tests need: active,
+// inactive, admin and soft deleted
+func seedFixtures(tx *gorm.DB, cfg *config.Config, logger *zerolog.Logger) error {
+	fixtures := []struct {
+		user    models.User
+		deleted bool
+	}{
+		{user: models.User{Email: "active@example.test", Username: "fixture-active", Role: models.RoleUser, IsActive: true}},
+		{user: models.User{Email: "inactive@example.test", Username: "fixture-inactive", Role: models.RoleUser, IsActive: false}},
+		{user: models.User{Email: "admin@example.test", Username: "fixture-admin", Role: models.RoleAdmin, IsActive: true}},
+		{user: models.User{Email: "deleted@example.test", Username: "fixture-deleted", Role: models.RoleUser, IsActive: true}, deleted: true},
+	}
+
+	for i := range fixtures {
+		user := &fixtures[i].user
+
+		created, err := ensureUser(tx, user, FixturePassword)
+		if err != nil {
+			return err
+		}
+
+		// is_active defaults to true in the database, so store false explicitly
+		if created && !user.IsActive {
+			if err := tx.Model(user).Update("is_active", false).Error; err != nil {
+				return fmt.Errorf("failed to deactivate user %s: %w", user.Email, err)
+			}
+		}
+		if created && fixtures[i].deleted {
+			if err := tx.Delete(user).Error; err != nil {
+				return fmt.Errorf("failed to delete user %s: %w", user.Email, err)
+			}
+		}
+
+		logUser(logger, user, created)
+	}
+
+	return nil
+}
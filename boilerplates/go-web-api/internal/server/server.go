@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/go-web-api/internal/config"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+var ErrTLSNotConfigured = errors.New("TLS enabled but neither cert files nor autocert domains are configured")
+
+// redirectPortOff is the TLS_REDIRECT_PORT value that disables the redirect
+// server when serving certificates from files
+const redirectPortOff = "off"
+
+// Server wraps the main HTTP server and the optional HTTP→HTTPS redirect server
+type Server struct {
+	cfg      *config.Config
+	logger   *zerolog.Logger
+	main     *http.Server
+	redirect *http.Server
+	useFiles bool
+}
+
+// New creates a new server for the given handler, configuring TLS when enabled
+func New(cfg *config.Config, handler http.Handler, logger *zerolog.Logger) (*Server, error) {
+	s := &Server{
+		cfg:    cfg,
+		logger: logger,
+		main: &http.Server{
+			Addr:              ":" + cfg.AppPort,
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+
+	if !cfg.TLSEnabled {
+		return s, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	redirectHandler := http.HandlerFunc(s.redirectToHTTPS)
+
+	switch {
+	case cfg.TLSCertFile != "" && cfg.TLSKeyFile != "":
+		// Certificates are provided on disk, loaded by ListenAndServeTLS
+		s.useFiles = true
+		s.main.TLSConfig = tlsConfig
+		if redirectEnabled(cfg.TLSRedirectPort) {
+			s.redirect = newRedirectServer(cfg.TLSRedirectPort, redirectHandler)
+		}
+	case len(cfg.TLSAutocertDomains) > 0:
+		// Certificates are obtained and renewed from Let's Encrypt
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.TLSAutocertDomains...),
+			Cache:      autocert.DirCache(cfg.TLSAutocertCacheDir),
+			Email:      cfg.TLSAutocertEmail,
+		}
+		tlsConfig.GetCertificate = manager.GetCertificate
+		tlsConfig.NextProtos = append([]string{"h2", "http/1.1"}, tlsConfig.NextProtos...)
+		s.main.TLSConfig = tlsConfig
+
+		// The HTTP-01 challenge must be answered on port 80, so the
+		// redirect server is always started in autocert mode
+		port := cfg.TLSRedirectPort
+		if !redirectEnabled(port) {
+			port = "80"
+		}
+		s.redirect = newRedirectServer(port, manager.HTTPHandler(redirectHandler))
+	default:
+		return nil, ErrTLSNotConfigured
+	}
+
+	return s, nil
+}
+
+// Start starts the server and blocks until it stops
+func (s *Server) Start() error {
+	if s.redirect != nil {
+		go func() {
+			s.logger.Info().Msgf("Starting HTTP redirect server on %s", s.redirect.Addr)
+			if err := s.redirect.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				s.logger.Error().Err(err).Msg("HTTP redirect server failed")
+			}
+		}()
+	}
+
+	var err error
+	switch {
+	case !s.cfg.TLSEnabled:
+		s.logger.Info().Msgf("Starting server on %s", s.main.Addr)
+		err = s.main.ListenAndServe()
+	case s.useFiles:
+		s.logger.Info().Msgf("Starting TLS server on %s", s.main.Addr)
+		err = s.main.ListenAndServeTLS(s.cfg.TLSCertFile, s.cfg.TLSKeyFile)
+	default:
+		s.logger.Info().Msgf("Starting TLS server on %s with automatic certificates", s.main.Addr)
+		err = s.main.ListenAndServeTLS("", "")
+	}
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("server failed: %w", err)
+	}
+	return nil
+}
+
+// Shutdown gracefully stops the server and the redirect server
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.redirect != nil {
+		if err := s.redirect.Shutdown(ctx); err != nil {
+			s.logger.Error().Err(err).Msg("Failed to shut down HTTP redirect server")
+		}
+	}
+	return s.main.Shutdown(ctx)
+}
+
+// redirectToHTTPS redirects plain HTTP requests to the HTTPS listener
+func (s *Server) redirectToHTTPS(w http.ResponseWriter, r *http.Request) {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if s.cfg.AppPort != "443" {
+		host = net.JoinHostPort(host, s.cfg.AppPort)
+	}
+
+	http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+}
+
+// redirectEnabled reports whether a TLS_REDIRECT_PORT value asks for a
+// redirect server. "off" and "0" disable it.
+func redirectEnabled(port string) bool {
+	return port != "" && port != redirectPortOff && port != "0"
+}
+
+func newRedirectServer(port string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + port,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
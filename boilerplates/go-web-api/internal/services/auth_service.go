@@ -0,0 +1,362 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/go-web-api/internal/config"
+	"github.com/yourusername/go-web-api/internal/models"
+	"github.com/yourusername/go-web-api/internal/repository"
+	"github.com/yourusername/go-web-api/internal/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidCredentials  = errors.New("invalid email or password")
+	ErrUserInactive        = errors.New("user is inactive")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrSessionExpired      = errors.New("session expired or revoked")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+)
+
+// sessionTouchInterval limits how often a session's last activity is written
+const sessionTouchInterval = time.Minute
+
+// AuthService handles authentication and session management
+type AuthService interface {
+	Login(ctx context.Context, req *models.LoginRequest, userAgent, ipAddress string) (*models.LoginResponse, error)
+	Refresh(ctx context.Context, req *models.RefreshRequest) (*models.LoginResponse, error)
+	ValidateSession(ctx context.Context, userID uint, sessionID string) error
+	ListSessions(ctx context.Context, userID uint) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userID uint, sessionID string) error
+	LogoutAll(ctx context.Context, userID uint) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type authService struct {
+	userRepo      repository.UserRepository
+	sessionRepo   repository.SessionRepository
+	tokenRepo     repository.RefreshTokenRepository
+	jwtSecret     string
+	jwtExpiry     time.Duration
+	refreshExpiry time.Duration
+	// idleTimeout limits inactivity between requests made with access tokens
+	idleTimeout time.Duration
+	// refreshIdleTimeout limits inactivity before a refresh; 0 disables it
+	refreshIdleTimeout time.Duration
+	maxSessions        int
+}
+
+// NewAuthService creates a new auth service
+func NewAuthService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, tokenRepo repository.RefreshTokenRepository, cfg *config.Config) AuthService {
+	return &authService{
+		userRepo:      userRepo,
+		sessionRepo:   sessionRepo,
+		tokenRepo:     tokenRepo,
+		jwtSecret:     cfg.JWTSecret,
+		jwtExpiry:     parseDuration(cfg.JWTExpiry, 15*time.Minute),
+		refreshExpiry: parseDuration(cfg.RefreshTokenExpiry, 30*24*time.Hour),
+		idleTimeout:   parseDuration(cfg.SessionIdleTimeout, 30*time.Minute),
+		// A refresh comes after the access token expired, so it is idle for at
+		// least that long and needs its own, usually much longer, timeout
+		refreshIdleTimeout: parseDuration(cfg.SessionRefreshIdleTimeout, 7*24*time.Hour),
+		maxSessions:        cfg.SessionMaxPerUser,
+	}
+}
+
+// Login verifies the credentials, opens a new session and issues a token pair
+func (s *authService) Login(ctx context.Context, req *models.LoginRequest, userAgent, ipAddress string) (*models.LoginResponse, error) {
+	user, err := s.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if !utils.CheckPassword(req.Password, user.Password) {
+		return nil, ErrInvalidCredentials
+	}
+
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	now := time.Now().UTC()
+
+	sessionID, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session ID: %w", err)
+	}
+
+	session := &models.Session{
+		ID:             sessionID,
+		UserID:         user.ID,
+		UserAgent:      userAgent,
+		IPAddress:      ipAddress,
+		LastActivityAt: now,
+		ExpiresAt:      now.Add(s.refreshExpiry),
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	if err := s.enforceSessionLimit(ctx, user.ID, now); err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, user, session.ID, now)
+}
+
+// Refresh rotates a refresh token and issues a new token pair for its session.
+// Presenting an already rotated token revokes the whole session, since it
+// indicates the token has been stolen.
+func (s *authService) Refresh(ctx context.Context, req *models.RefreshRequest) (*models.LoginResponse, error) {
+	token, err := s.tokenRepo.GetByHash(ctx, utils.HashToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	if token.RevokedAt != nil {
+		if err := s.revokeSession(ctx, token.SessionID, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if now.After(token.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Revoke the presented token first so concurrent refreshes cannot both succeed
+	revoked, err := s.tokenRepo.Revoke(ctx, token.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	if !revoked {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, token.SessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session.RevokedAt != nil || now.After(session.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if s.refreshIdleTimeout > 0 && now.Sub(session.LastActivityAt) > s.refreshIdleTimeout {
+		if err := s.revokeSession(ctx, session.ID, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	if err := s.sessionRepo.Extend(ctx, session.ID, now, now.Add(s.refreshExpiry)); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return s.issueTokens(ctx, user, session.ID, now)
+}
+
+// ValidateSession checks that a session is still active and records activity on it
+func (s *authService) ValidateSession(ctx context.Context, userID uint, sessionID string) error {
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session.UserID != userID || session.RevokedAt != nil {
+		return ErrSessionExpired
+	}
+
+	now := time.Now().UTC()
+	if now.After(session.ExpiresAt) {
+		return ErrSessionExpired
+	}
+
+	// Expire sessions that have been idle for too long
+	if s.idleTimeout > 0 && now.Sub(session.LastActivityAt) > s.idleTimeout {
+		if err := s.revokeSession(ctx, session.ID, now); err != nil {
+			return err
+		}
+		return ErrSessionExpired
+	}
+
+	if now.Sub(session.LastActivityAt) >= sessionTouchInterval {
+		if err := s.sessionRepo.Touch(ctx, session.ID, now); err != nil {
+			return fmt.Errorf("failed to update session: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListSessions retrieves the active sessions of a user
+func (s *authService) ListSessions(ctx context.Context, userID uint) ([]models.Session, error) {
+	now := time.Now().UTC()
+	sessions, err := s.sessionRepo.ListActiveByUser(ctx, userID, now, s.activeSince(now))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// RevokeSession revokes one of the user's sessions
+func (s *authService) RevokeSession(ctx context.Context, userID uint, sessionID string) error {
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	// Do not reveal other users' sessions
+	if session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	return s.revokeSession(ctx, session.ID, time.Now().UTC())
+}
+
+// LogoutAll revokes every session and refresh token of a user
+func (s *authService) LogoutAll(ctx context.Context, userID uint) error {
+	now := time.Now().UTC()
+
+	if err := s.sessionRepo.RevokeByUser(ctx, userID, now); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	if err := s.tokenRepo.RevokeByUser(ctx, userID, now); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeExpired deletes expired sessions and refresh tokens and returns how many were removed
+func (s *authService) PurgeExpired(ctx context.Context) (int64, error) {
+	now := time.Now().UTC()
+
+	tokens, err := s.tokenRepo.DeleteExpired(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired refresh tokens: %w", err)
+	}
+
+	sessions, err := s.sessionRepo.DeleteExpired(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+
+	return tokens + sessions, nil
+}
+
+// issueTokens creates a new refresh token for the session and signs an access token
+func (s *authService) issueTokens(ctx context.Context, user *models.User, sessionID string, now time.Time) (*models.LoginResponse, error) {
+	refreshToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	if err := s.tokenRepo.Create(ctx, &models.RefreshToken{
+		SessionID: sessionID,
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: now.Add(s.refreshExpiry),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	accessToken, err := utils.GenerateToken(user.ID, user.Email, user.Role, sessionID, s.jwtSecret, s.jwtExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return &models.LoginResponse{
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(s.jwtExpiry.Seconds()),
+		RefreshExpiresIn: int64(s.refreshExpiry.Seconds()),
+		User:             user.ToResponse(),
+	}, nil
+}
+
+// activeSince returns the last activity time before which a session can no
+// longer be refreshed. Such sessions are only revoked when next used, so
+// queries for active sessions must exclude them. Sessions past the access
+// token idle timeout are still alive, since a refresh revives them.
+func (s *authService) activeSince(now time.Time) time.Time {
+	if s.refreshIdleTimeout <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-s.refreshIdleTimeout)
+}
+
+// enforceSessionLimit revokes the oldest sessions of a user beyond the
+// concurrent session limit. Idle sessions are already dead and do not count.
+// It runs after the new session is stored, so concurrent logins each see the
+// sessions created before their own and the last one to list them trims the
+// user back to the limit.
+func (s *authService) enforceSessionLimit(ctx context.Context, userID uint, now time.Time) error {
+	if s.maxSessions <= 0 {
+		return nil
+	}
+
+	active, err := s.sessionRepo.ListActiveByUser(ctx, userID, now, s.activeSince(now))
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+	for i := 0; i < len(active)-s.maxSessions; i++ {
+		if err := s.revokeSession(ctx, active[i].ID, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revokeSession revokes a session together with its refresh tokens
+func (s *authService) revokeSession(ctx context.Context, sessionID string, now time.Time) error {
+	if err := s.sessionRepo.Revoke(ctx, sessionID, now); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	if err := s.tokenRepo.RevokeBySession(ctx, sessionID, now); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+// parseDuration parses a duration string, falling back to the default on error
+func parseDuration(value string, defaultValue time.Duration) time.Duration {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue
+	}
+	return d
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yourusername/go-web-api/internal/config"
+	"github.com/yourusername/go-web-api/internal/models"
+	"github.com/yourusername/go-web-api/internal/repository"
+	"github.com/yourusername/go-web-api/internal/utils"
+	"gorm.io/gorm"
+)
+
+// fakeUserRepository is an in-memory UserRepository for service tests
+type fakeUserRepository struct {
+	repository.UserRepository
+	users map[uint]*models.User
+}
+
+func (r *fakeUserRepository) GetByID(ctx context.Context, id uint) (*models.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	c := *user
+	return &c, nil
+}
+
+// fakeSessionRepository is an in-memory SessionRepository for service tests
+type fakeSessionRepository struct {
+	repository.SessionRepository
+	sessions map[string]*models.Session
+}
+
+func (r *fakeSessionRepository) GetByID(ctx context.Context, id string) (*models.Session, error) {
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	c := *session
+	return &c, nil
+}
+
+func (r *fakeSessionRepository) Extend(ctx context.Context, id string, at, expiresAt time.Time) error {
+	r.sessions[id].LastActivityAt = at
+	r.sessions[id].ExpiresAt = expiresAt
+	return nil
+}
+
+func (r *fakeSessionRepository) Revoke(ctx context.Context, id string, at time.Time) error {
+	if session, ok := r.sessions[id]; ok && session.RevokedAt == nil {
+		session.RevokedAt = &at
+	}
+	return nil
+}
+
+// fakeRefreshTokenRepository is an in-memory RefreshTokenRepository for service tests
+type fakeRefreshTokenRepository struct {
+	repository.RefreshTokenRepository
+	tokens []*models.RefreshToken
+}
+
+func (r *fakeRefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	token.ID = uint(len(r.tokens) + 1)
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *fakeRefreshTokenRepository) GetByHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == hash {
+			c := *token
+			return &c, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeRefreshTokenRepository) Revoke(ctx context.Context, id uint, at time.Time) (bool, error) {
+	for _, token := range r.tokens {
+		if token.ID == id && token.RevokedAt == nil {
+			token.RevokedAt = &at
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeRefreshTokenRepository) RevokeBySession(ctx context.Context, sessionID string, at time.Time) error {
+	for _, token := range r.tokens {
+		if token.SessionID == sessionID && token.RevokedAt == nil {
+			token.RevokedAt = &at
+		}
+	}
+	return nil
+}
+
+// defaultConfig loads the configuration with the session settings at their defaults
+func defaultConfig(t *testing.T) *config.Config {
+	t.Helper()
+	for _, key := range []string{"JWT_EXPIRY", "REFRESH_TOKEN_EXPIRY", "SESSION_IDLE_TIMEOUT", "SESSION_REFRESH_IDLE_TIMEOUT", "SESSION_MAX_PER_USER"} {
+		t.Setenv(key, "")
+	}
+	return config.Load()
+}
+
+// newRefreshFixture returns an auth service with the default configuration
+// and a refresh token for a session last used idle ago
+func newRefreshFixture(t *testing.T, idle time.Duration) (AuthService, *fakeSessionRepository, string) {
+	t.Helper()
+
+	now := time.Now().UTC()
+	user := &models.User{Email: "user@example.test", Username: "user", Role: models.RoleUser, IsActive: true}
+	user.ID = 1
+
+	sessions := &fakeSessionRepository{sessions: map[string]*models.Session{
+		"session": {
+			ID:             "session",
+			UserID:         user.ID,
+			LastActivityAt: now.Add(-idle),
+			ExpiresAt:      now.Add(24 * time.Hour),
+		},
+	}}
+	tokens := &fakeRefreshTokenRepository{}
+	if err := tokens.Create(context.Background(), &models.RefreshToken{
+		SessionID: "session",
+		UserID:    user.ID,
+		TokenHash: utils.HashToken("refresh-token"),
+		ExpiresAt: now.Add(24 * time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	users := &fakeUserRepository{users: map[uint]*models.User{user.ID: user}}
+	service := NewAuthService(users, sessions, tokens, defaultConfig(t))
+
+	return service, sessions, "refresh-token"
+}
+
+func TestRefreshRejectsIdleSessionWithDefaultConfig(t *testing.T) {
+	service, sessions, refreshToken := newRefreshFixture(t, 8*24*time.Hour)
+
+	_, err := service.Refresh(context.Background(), &models.RefreshRequest{RefreshToken: refreshToken})
+	if !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("Refresh() error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+	if sessions.sessions["session"].RevokedAt == nil {
+		t.Error("idle session was not revoked")
+	}
+}
+
+func TestRefreshAcceptsSessionIdleBeyondAccessTimeout(t *testing.T) {
+	// Longer than SESSION_IDLE_TIMEOUT and JWT_EXPIRY, but within the refresh idle timeout
+	service, sessions, refreshToken := newRefreshFixture(t, 2*time.Hour)
+
+	resp, err := service.Refresh(context.Background(), &models.RefreshRequest{RefreshToken: refreshToken})
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if resp.AccessToken == "" || resp.RefreshToken == "" {
+		t.Error("Refresh() did not issue a token pair")
+	}
+	if sessions.sessions["session"].RevokedAt != nil {
+		t.Error("session was revoked")
+	}
+}
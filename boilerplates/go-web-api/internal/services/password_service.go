@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/yourusername/go-web-api/internal/email"
+	"github.com/yourusername/go-web-api/internal/models"
+	"github.com/yourusername/go-web-api/internal/repository"
+	"github.com/yourusername/go-web-api/internal/utils"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+
+// PasswordService handles the forgot-password and reset-password flow
+type PasswordService interface {
+	ForgotPassword(ctx context.Context, req *models.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req *models.ResetPasswordRequest) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// PasswordConfig holds password reset settings
+type PasswordConfig struct {
+	AppName     string
+	ResetURL    string
+	ResetExpiry time.Duration
+}
+
+type passwordService struct {
+	userRepo    repository.UserRepository
+	resetRepo   repository.PasswordResetRepository
+	userService UserService
+	authService AuthService
+	renderer    *email.Renderer
+	mailQueue   email.Queue
+	cfg         PasswordConfig
+}
+
+// NewPasswordService creates a new password service
+func NewPasswordService(userRepo repository.UserRepository, resetRepo repository.PasswordResetRepository, userService UserService, authService AuthService, renderer *email.Renderer, mailQueue email.Queue, cfg PasswordConfig) PasswordService {
+	return &passwordService{
+		userRepo:    userRepo,
+		resetRepo:   resetRepo,
+		userService: userService,
+		authService: authService,
+		renderer:    renderer,
+		mailQueue:   mailQueue,
+		cfg:         cfg,
+	}
+}
+
+// ForgotPassword issues a reset token and emails the reset link. Unknown or
+// inactive accounts are ignored so the endpoint does not reveal which emails exist.
+func (s *passwordService) ForgotPassword(ctx context.Context, req *models.ForgotPasswordRequest) error {
+	user, err := s.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsActive {
+		return nil
+	}
+
+	now := time.Now().UTC()
+
+	// Only the most recent reset link stays valid
+	if err := s.resetRepo.InvalidateByUser(ctx, user.ID, now); err != nil {
+		return fmt.Errorf("failed to invalidate reset tokens: %w", err)
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate reset token: %w", err)
+	}
+
+	if err := s.resetRepo.Create(ctx, &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: now.Add(s.cfg.ResetExpiry),
+	}); err != nil {
+		return fmt.Errorf("failed to store reset token: %w", err)
+	}
+
+	resetURL, err := url.Parse(s.cfg.ResetURL)
+	if err != nil {
+		return fmt.Errorf("invalid reset URL: %w", err)
+	}
+	q := resetURL.Query()
+	q.Set("token", token)
+	resetURL.RawQuery = q.Encode()
+
+	msg, err := s.renderer.Render("password_reset", "Reset your password", []string{user.Email}, map[string]interface{}{
+		"AppName":   s.cfg.AppName,
+		"Name":      displayName(user),
+		"ResetURL":  resetURL.String(),
+		"ExpiresIn": formatDuration(s.cfg.ResetExpiry),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.mailQueue.Enqueue(msg); err != nil {
+		return fmt.Errorf("failed to queue reset email: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password using a reset token and signs the user
+// out of every session
+func (s *passwordService) ResetPassword(ctx context.Context, req *models.ResetPasswordRequest) error {
+	token, err := s.resetRepo.GetByHash(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return fmt.Errorf("failed to get reset token: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if token.UsedAt != nil || now.After(token.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	// Mark the token used first so concurrent requests cannot use it twice
+	used, err := s.resetRepo.MarkUsed(ctx, token.ID, now)
+	if err != nil {
+		return fmt.Errorf("failed to use reset token: %w", err)
+	}
+	if !used {
+		return ErrInvalidResetToken
+	}
+
+	if err := s.userService.SetPassword(ctx, token.UserID, req.Password); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	if err := s.authService.LogoutAll(ctx, token.UserID); err != nil {
+		return err
+	}
+
+	// The confirmation email is best effort; the password has already changed
+	if user, err := s.userRepo.GetByID(ctx, token.UserID); err == nil {
+		msg, err := s.renderer.Render("password_changed", "Your password was changed", []string{user.Email}, map[string]interface{}{
+			"AppName": s.cfg.AppName,
+			"Name":    displayName(user),
+		})
+		if err == nil {
+			_ = s.mailQueue.Enqueue(msg)
+		}
+	}
+
+	return nil
+}
+
+// PurgeExpired deletes expired password reset tokens and returns how many were removed
+func (s *passwordService) PurgeExpired(ctx context.Context) (int64, error) {
+	removed, err := s.resetRepo.DeleteExpired(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired reset tokens: %w", err)
+	}
+	return removed, nil
+}
+
+// displayName returns the name used to greet a user in emails
+func displayName(user *models.User) string {
+	if user.FirstName != "" {
+		return user.FirstName
+	}
+	return user.Username
+}
+
+// formatDuration formats a duration for humans, e.g. "1 hour" or "30 minutes"
+func formatDuration(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		if d == time.Hour {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", d/time.Hour)
+	}
+	minutes := int(d.Minutes())
+	if minutes == 1 {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yourusername/go-web-api/internal/models"
+	"github.com/yourusername/go-web-api/internal/repository"
+	"github.com/yourusername/go-web-api/internal/storage"
+	"github.com/yourusername/go-web-api/internal/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUploadNotFound     = errors.New("upload not found")
+	ErrFileTooLarge       = errors.New("file is too large")
+	ErrFileTypeNotAllowed = errors.New("file type is not allowed")
+	ErrInvalidDownloadURL = errors.New("invalid or expired download URL")
+)
+
+// UploadService handles business logic for file uploads
+type UploadService interface {
+	Upload(ctx context.Context, userID uint, file *multipart.FileHeader) (*models.Upload, error)
+	GetByID(ctx context.Context, userID, id uint) (*models.Upload, error)
+	DownloadURL(ctx context.Context, upload *models.Upload) (string, error)
+	OpenSigned(ctx context.Context, key, expires, filename, signature string) (io.ReadCloser, *models.Upload, error)
+	Delete(ctx context.Context, userID, id uint) error
+}
+
+// UploadConfig holds upload validation settings
+type UploadConfig struct {
+	MaxSize      int64
+	AllowedTypes []string
+	URLExpiry    time.Duration
+}
+
+type uploadService struct {
+	repo    repository.UploadRepository
+	storage storage.Storage
+	cfg     UploadConfig
+}
+
+// NewUploadService creates a new upload service
+func NewUploadService(repo repository.UploadRepository, store storage.Storage, cfg UploadConfig) UploadService {
+	return &uploadService{repo: repo, storage: store, cfg: cfg}
+}
+
+// Upload validates a multipart file, stores it and records its metadata
+func (s *uploadService) Upload(ctx context.Context, userID uint, file *multipart.FileHeader) (*models.Upload, error) {
+	if file.Size > s.cfg.MaxSize {
+		return nil, ErrFileTooLarge
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	// Detect the content type from the file contents rather than trusting the client
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	contentType := http.DetectContentType(head[:n])
+	if !s.isAllowed(contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrFileTypeNotAllowed, contentType)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	name, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate file key: %w", err)
+	}
+
+	filename := filepath.Base(file.Filename)
+	key := fmt.Sprintf("uploads/%d/%s%s", userID, name, strings.ToLower(filepath.Ext(filename)))
+
+	if err := s.storage.Put(ctx, key, f, file.Size, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+
+	upload := &models.Upload{
+		UserID:      userID,
+		Key:         key,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        file.Size,
+	}
+	if err := s.repo.Create(ctx, upload); err != nil {
+		// Do not leave orphaned files behind
+		_ = s.storage.Delete(ctx, key)
+		return nil, fmt.Errorf("failed to create upload: %w", err)
+	}
+
+	return upload, nil
+}
+
+// GetByID retrieves one of the user's uploads
+func (s *uploadService) GetByID(ctx context.Context, userID, id uint) (*models.Upload, error) {
+	upload, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, fmt.Errorf("failed to get upload: %w", err)
+	}
+
+	// Do not reveal other users' uploads
+	if upload.UserID != userID {
+		return nil, ErrUploadNotFound
+	}
+
+	return upload, nil
+}
+
+// DownloadURL returns a signed, expiring download URL for an upload
+func (s *uploadService) DownloadURL(ctx context.Context, upload *models.Upload) (string, error) {
+	url, err := s.storage.SignedURL(ctx, upload.Key, upload.Filename, s.cfg.URLExpiry)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign download URL: %w", err)
+	}
+	return url, nil
+}
+
+// OpenSigned verifies a signed download URL issued by the local storage
+// backend and opens the file. The returned upload carries the content type
+// detected when the file was uploaded.
+func (s *uploadService) OpenSigned(ctx context.Context, key, expires, filename, signature string) (io.ReadCloser, *models.Upload, error) {
+	local, ok := s.storage.(*storage.LocalStorage)
+	if !ok {
+		return nil, nil, ErrUploadNotFound
+	}
+
+	if err := local.Verify(key, expires, filename, signature); err != nil {
+		return nil, nil, ErrInvalidDownloadURL
+	}
+
+	upload, err := s.repo.GetByKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrUploadNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get upload: %w", err)
+	}
+
+	r, err := local.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, nil, ErrUploadNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return r, upload, nil
+}
+
+// Delete deletes one of the user's uploads and its file
+func (s *uploadService) Delete(ctx context.Context, userID, id uint) error {
+	upload, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, upload.ID); err != nil {
+		return fmt.Errorf("failed to delete upload: %w", err)
+	}
+
+	if err := s.storage.Delete(ctx, upload.Key); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
+
+// isAllowed reports whether a content type matches the allowed types. Entries
+// may use a wildcard subtype, e.g. "image/*".
+func (s *uploadService) isAllowed(contentType string) bool {
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	for _, allowed := range s.cfg.AllowedTypes {
+		if allowed == mediaType {
+			return true
+		}
+		if strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+	}
+	return false
+}
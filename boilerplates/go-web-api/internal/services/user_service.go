@@ -1,50 +1,62 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/yourusername/go-web-api/internal/audit"
+	"github.com/yourusername/go-web-api/internal/cache"
 	"github.com/yourusername/go-web-api/internal/models"
 	"github.com/yourusername/go-web-api/internal/repository"
 	"github.com/yourusername/go-web-api/internal/utils"
+	"github.com/yourusername/go-web-api/pkg/query"
 	"gorm.io/gorm"
 )
 
 var (
-	ErrUserNotFound      = errors.New("user not found")
-	ErrEmailAlreadyExists = errors.New("email already exists")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrEmailAlreadyExists    = errors.New("email already exists")
 	ErrUsernameAlreadyExists = errors.New("username already exists")
+	ErrUserVersionConflict   = errors.New("user was modified by another request")
 )
 
 // UserService handles business logic for users
 type UserService interface {
-	Create(req *models.UserCreateRequest) (*models.User, error)
-	GetByID(id uint) (*models.User, error)
-	List(page, pageSize int) ([]models.User, int64, error)
-	Update(id uint, req *models.UserUpdateRequest) (*models.User, error)
-	Delete(id uint) error
+	Create(ctx context.Context, req *models.UserCreateRequest) (*models.User, error)
+	GetByID(ctx context.Context, id uint) (*models.User, error)
+	List(ctx context.Context, params *query.Params) ([]models.User, int64, error)
+	ListDeleted(ctx context.Context, params *query.Params) ([]models.User, int64, error)
+	Update(ctx context.Context, id uint, req *models.UserUpdateRequest) (*models.User, error)
+	Delete(ctx context.Context, id uint) error
+	Restore(ctx context.Context, id uint) (*models.User, error)
+	SetPassword(ctx context.Context, id uint, password string) error
 }
 
 type userService struct {
-	repo repository.UserRepository
+	repo        repository.UserRepository
+	authService AuthService
+	cache       cache.Cache
+	cacheTTL    time.Duration
 }
 
 // NewUserService creates a new user service
-func NewUserService(repo repository.UserRepository) UserService {
-	return &userService{repo: repo}
+func NewUserService(repo repository.UserRepository, authService AuthService, store cache.Cache, cacheTTL time.Duration) UserService {
+	return &userService{repo: repo, authService: authService, cache: store, cacheTTL: cacheTTL}
 }
 
-// Create creates a new user
-func (s *userService) Create(req *models.UserCreateRequest) (*models.User, error) {
+// Create creates a new user on behalf of the actor in ctx, if any
+func (s *userService) Create(ctx context.Context, req *models.UserCreateRequest) (*models.User, error) {
 	// Check if email already exists
-	if _, err := s.repo.GetByEmail(req.Email); err == nil {
+	if _, err := s.repo.GetByEmail(ctx, req.Email); err == nil {
 		return nil, ErrEmailAlreadyExists
 	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, fmt.Errorf("failed to check email: %w", err)
 	}
 
 	// Check if username already exists
-	if _, err := s.repo.GetByUsername(req.Username); err == nil {
+	if _, err := s.repo.GetByUsername(ctx, req.Username); err == nil {
 		return nil, ErrUsernameAlreadyExists
 	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, fmt.Errorf("failed to check username: %w", err)
@@ -63,19 +75,24 @@ func (s *userService) Create(req *models.UserCreateRequest) (*models.User, error
 		Password:  hashedPassword,
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
+		Role:      models.RoleUser,
 		IsActive:  true,
 	}
 
-	if err := s.repo.Create(user); err != nil {
+	if err := s.repo.Create(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
 	return user, nil
 }
 
-// GetByID retrieves a user by ID
-func (s *userService) GetByID(id uint) (*models.User, error) {
-	user, err := s.repo.GetByID(id)
+// GetByID retrieves a user by ID using the cache-aside pattern. Cached users
+// are JSON encoded, so fields hidden from JSON (such as the password hash) are
+// not populated; use the repository when those are needed.
+func (s *userService) GetByID(ctx context.Context, id uint) (*models.User, error) {
+	user, err := cache.Remember(ctx, s.cache, cache.UserKey(id), s.cacheTTL, func() (*models.User, error) {
+		return s.repo.GetByID(ctx, id)
+	})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
@@ -85,27 +102,30 @@ func (s *userService) GetByID(id uint) (*models.User, error) {
 	return user, nil
 }
 
-// List retrieves a paginated list of users
-func (s *userService) List(page, pageSize int) ([]models.User, int64, error) {
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+// List retrieves a filtered, sorted and paginated list of users
+func (s *userService) List(ctx context.Context, params *query.Params) ([]models.User, int64, error) {
+	users, total, err := s.repo.List(ctx, params)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
 	}
 
-	offset := (page - 1) * pageSize
-	users, total, err := s.repo.List(pageSize, offset)
+	return users, total, nil
+}
+
+// ListDeleted retrieves a filtered, sorted and paginated list of soft deleted users
+func (s *userService) ListDeleted(ctx context.Context, params *query.Params) ([]models.User, int64, error) {
+	users, total, err := s.repo.ListDeleted(ctx, params)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+		return nil, 0, fmt.Errorf("failed to list deleted users: %w", err)
 	}
 
 	return users, total, nil
 }
 
-// Update updates a user
-func (s *userService) Update(id uint, req *models.UserUpdateRequest) (*models.User, error) {
-	user, err := s.repo.GetByID(id)
+// Update updates a user on behalf of the actor in ctx. If the request carries
+// a version, the update is rejected when the user has changed since then.
+func (s *userService) Update(ctx context.Context, id uint, req *models.UserUpdateRequest) (*models.User, error) {
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
@@ -113,10 +133,14 @@ func (s *userService) Update(id uint, req *models.UserUpdateRequest) (*models.Us
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	if req.Version != nil && *req.Version != user.Version {
+		return nil, ErrUserVersionConflict
+	}
+
 	// Update fields if provided
 	if req.Email != "" && req.Email != user.Email {
 		// Check if new email already exists
-		if _, err := s.repo.GetByEmail(req.Email); err == nil {
+		if _, err := s.repo.GetByEmail(ctx, req.Email); err == nil {
 			return nil, ErrEmailAlreadyExists
 		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("failed to check email: %w", err)
@@ -126,7 +150,7 @@ func (s *userService) Update(id uint, req *models.UserUpdateRequest) (*models.Us
 
 	if req.Username != "" && req.Username != user.Username {
 		// Check if new username already exists
-		if _, err := s.repo.GetByUsername(req.Username); err == nil {
+		if _, err := s.repo.GetByUsername(ctx, req.Username); err == nil {
 			return nil, ErrUsernameAlreadyExists
 		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("failed to check username: %w", err)
@@ -142,30 +166,128 @@ func (s *userService) Update(id uint, req *models.UserUpdateRequest) (*models.Us
 		user.LastName = req.LastName
 	}
 
+	deactivated := false
 	if req.IsActive != nil {
+		deactivated = user.IsActive && !*req.IsActive
 		user.IsActive = *req.IsActive
 	}
 
-	if err := s.repo.Update(user); err != nil {
+	if err := s.repo.Update(ctx, user); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return nil, ErrUserVersionConflict
+		}
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
+	s.invalidate(ctx, id)
+
+	// Sign a deactivated user out everywhere so existing tokens stop working
+	if deactivated {
+		if err := s.authService.LogoutAll(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
 	return user, nil
 }
 
-// Delete deletes a user
-func (s *userService) Delete(id uint) error {
+// Delete soft deletes a user on behalf of the actor in ctx
+func (s *userService) Delete(ctx context.Context, id uint) error {
 	// Check if user exists
-	if _, err := s.repo.GetByID(id); err != nil {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrUserNotFound
 		}
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
-	if err := s.repo.Delete(id); err != nil {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
+	s.invalidate(ctx, id)
+
+	// Sign a deleted user out everywhere so existing tokens stop working
+	return s.authService.LogoutAll(ctx, id)
+}
+
+// Restore restores a soft deleted user on behalf of the actor in ctx. It fails
+// if the email or username has been taken by another user in the meantime.
+func (s *userService) Restore(ctx context.Context, id uint) (*models.User, error) {
+	deleted, err := s.repo.GetDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get deleted user: %w", err)
+	}
+
+	if _, err := s.repo.GetByEmail(ctx, deleted.Email); err == nil {
+		return nil, ErrEmailAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+
+	if _, err := s.repo.GetByUsername(ctx, deleted.Username); err == nil {
+		return nil, ErrUsernameAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check username: %w", err)
+	}
+
+	if err := s.repo.Restore(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	s.invalidate(ctx, id)
+
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetPassword replaces a user's password. The change is recorded as made by
+// the user themselves.
+func (s *userService) SetPassword(ctx context.Context, id uint, password string) error {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.Password = hashedPassword
+
+	if err := s.repo.Update(audit.WithActor(ctx, id), user); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return ErrUserVersionConflict
+		}
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	s.invalidate(ctx, id)
+
 	return nil
 }
+
+// invalidate drops the cached user and any cached user responses. It runs
+// after a write has been committed, so it is not cut short by a cancelled
+// request.
+func (s *userService) invalidate(ctx context.Context, id uint) {
+	ctx = context.WithoutCancel(ctx)
+	_ = s.cache.Delete(ctx, cache.UserKey(id))
+	_ = s.cache.DeletePrefix(ctx, cache.ResponsePrefix("users"))
+}
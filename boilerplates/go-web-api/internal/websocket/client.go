@@ -0,0 +1,101 @@
+package websocket
+
+import (
+	"time"
+
+	ws "github.com/gorilla/websocket"
+)
+
+const (
+	// writeWait is the time allowed to write a message to the client
+	writeWait = 10 * time.Second
+	// pongWait is the time allowed to read the next pong from the client
+	pongWait = 60 * time.Second
+	// pingPeriod sends pings often enough to receive a pong within pongWait
+	pingPeriod = pongWait * 9 / 10
+	// maxMessageSize is the largest message accepted from the client
+	maxMessageSize = 512
+	// sendBufferSize is the number of messages queued for a client before it
+	// is considered too slow and disconnected
+	sendBufferSize = 64
+)
+
+// CheckFunc reports whether a connection may stay open, for example whether
+// the session it was opened with is still valid
+type CheckFunc func() error
+
+// Client is a single WebSocket connection
+type Client struct {
+	hub   *Hub
+	conn  *ws.Conn
+	send  chan []byte
+	check CheckFunc
+}
+
+// newClient creates a client for an upgraded connection
+func newClient(hub *Hub, conn *ws.Conn, check CheckFunc) *Client {
+	return &Client{
+		hub:   hub,
+		conn:  conn,
+		send:  make(chan []byte, sendBufferSize),
+		check: check,
+	}
+}
+
+// readPump keeps the read deadline alive on pongs and unregisters the client
+// when the connection fails. Messages from the client are discarded.
+func (c *Client) readPump() {
+	defer c.hub.unregister(c)
+
+	c.conn.SetReadLimit(maxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// writePump writes queued messages and pings to the connection. Before each
+// ping it re-runs the client's check. It closes the connection once the hub
+// closes the send channel, a write fails or the check fails.
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				_ = c.conn.WriteMessage(ws.CloseMessage, ws.FormatCloseMessage(ws.CloseNormalClosure, ""))
+				return
+			}
+			if err := c.conn.WriteMessage(ws.TextMessage, message); err != nil {
+				c.hub.unregister(c)
+				return
+			}
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if c.check != nil {
+				if err := c.check(); err != nil {
+					c.hub.logger.Info().Err(err).Msg("Closing websocket client")
+					_ = c.conn.WriteMessage(ws.CloseMessage, ws.FormatCloseMessage(ws.ClosePolicyViolation, "session expired or revoked"))
+					c.hub.unregister(c)
+					return
+				}
+			}
+			if err := c.conn.WriteMessage(ws.PingMessage, nil); err != nil {
+				c.hub.unregister(c)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	ws "github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+)
+
+var ErrHubClosed = errors.New("websocket hub is closed")
+
+// RoomAdmin is the room joined by connections of admin users
+const RoomAdmin = "admin"
+
+// UserRoom returns the room joined by all connections of a user
+func UserRoom(userID uint) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+// Message represents an event sent to clients
+type Message struct {
+	Type string      `json:"type"`
+	Room string      `json:"room"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+// Hub tracks connected clients by room and broadcasts messages to them.
+// Clients are assigned their rooms by the server when they connect.
+type Hub struct {
+	logger   *zerolog.Logger
+	upgrader ws.Upgrader
+
+	mu      sync.RWMutex
+	rooms   map[string]map[*Client]struct{}
+	clients map[*Client][]string
+	closed  bool
+}
+
+// NewHub creates a new hub accepting connections from the given origins.
+// "*" allows any origin.
+func NewHub(allowedOrigins []string, logger *zerolog.Logger) *Hub {
+	return &Hub{
+		logger: logger,
+		upgrader: ws.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     checkOrigin(allowedOrigins),
+		},
+		rooms:   make(map[string]map[*Client]struct{}),
+		clients: make(map[*Client][]string),
+	}
+}
+
+// Serve upgrades the request to a WebSocket connection and joins it to the
+// given rooms. The connection is served in the background until either side
+// closes it or check, which runs on every ping, fails. A nil check keeps the
+// connection open.
+func (h *Hub) Serve(w http.ResponseWriter, r *http.Request, check CheckFunc, rooms ...string) error {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade connection: %w", err)
+	}
+
+	client := newClient(h, conn, check)
+	if err := h.register(client, rooms); err != nil {
+		_ = conn.WriteMessage(ws.CloseMessage, ws.FormatCloseMessage(ws.CloseGoingAway, "server shutting down"))
+		_ = conn.Close()
+		return err
+	}
+
+	go client.writePump()
+	go client.readPump()
+
+	return nil
+}
+
+// Broadcast sends a message to every client in a room. Clients that cannot
+// keep up are disconnected rather than blocking the sender.
+func (h *Hub) Broadcast(room, msgType string, data interface{}) error {
+	payload, err := json.Marshal(Message{Type: msgType, Room: room, Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	var slow []*Client
+
+	h.mu.RLock()
+	for client := range h.rooms[room] {
+		select {
+		case client.send <- payload:
+		default:
+			slow = append(slow, client)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, client := range slow {
+		h.logger.Warn().Str("room", room).Msg("Dropping slow websocket client")
+		h.unregister(client)
+	}
+
+	return nil
+}
+
+// Count returns the number of clients in a room
+func (h *Hub) Count(room string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.rooms[room])
+}
+
+// Close disconnects all clients and rejects new connections
+func (h *Hub) Close() {
+	h.mu.Lock()
+	h.closed = true
+	clients := make([]*Client, 0, len(h.clients))
+	for client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.Unlock()
+
+	for _, client := range clients {
+		h.unregister(client)
+	}
+}
+
+// register adds a client to its rooms
+func (h *Hub) register(client *Client, rooms []string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return ErrHubClosed
+	}
+
+	h.clients[client] = rooms
+	for _, room := range rooms {
+		if h.rooms[room] == nil {
+			h.rooms[room] = make(map[*Client]struct{})
+		}
+		h.rooms[room][client] = struct{}{}
+	}
+	return nil
+}
+
+// unregister removes a client from its rooms and stops its write pump. It is
+// safe to call more than once.
+func (h *Hub) unregister(client *Client) {
+	h.mu.Lock()
+	rooms, ok := h.clients[client]
+	if ok {
+		delete(h.clients, client)
+		for _, room := range rooms {
+			delete(h.rooms[room], client)
+			if len(h.rooms[room]) == 0 {
+				delete(h.rooms, room)
+			}
+		}
+	}
+	h.mu.Unlock()
+
+	if ok {
+		close(client.send)
+	}
+}
+
+// checkOrigin returns an origin check allowing the configured origins.
+// Requests without an Origin header do not come from browsers and are allowed.
+func checkOrigin(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		for _, allowed := range allowedOrigins {
+			if allowed == "*" || allowed == origin {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// JobStatus represents the lifecycle state of a job
+type JobStatus string
+
+const (
+	StatusScheduled JobStatus = "scheduled"
+	StatusQueued    JobStatus = "queued"
+	StatusRunning   JobStatus = "running"
+	StatusRetrying  JobStatus = "retrying"
+	StatusSucceeded JobStatus = "succeeded"
+	StatusFailed    JobStatus = "failed"
+)
+
+// HandlerFunc processes the payload of a job. Returning an error schedules a
+// retry until the job's retry budget is exhausted.
+type HandlerFunc func(ctx context.Context, payload json.RawMessage) error
+
+// Job represents a unit of background work
+type Job struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"-"` // May hold secrets such as rendered emails; never exposed
+	Status     JobStatus       `json:"status"`
+	Attempts   int             `json:"attempts"`
+	MaxRetries int             `json:"max_retries"`
+	LastError  string          `json:"last_error,omitempty"`
+	RunAt      time.Time       `json:"run_at"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// Option configures a job when it is enqueued
+type Option func(*Job)
+
+// WithMaxRetries overrides the default number of retries for a job
+func WithMaxRetries(n int) Option {
+	return func(j *Job) {
+		j.MaxRetries = n
+	}
+}
+
+// WithDelay defers the first run of a job
+func WithDelay(d time.Duration) Option {
+	return func(j *Job) {
+		j.RunAt = j.RunAt.Add(d)
+	}
+}
+
+// Stats represents a snapshot of worker activity
+type Stats struct {
+	Workers    int               `json:"workers"`
+	QueueDepth int               `json:"queue_depth"`
+	Registered []string          `json:"registered"`
+	Schedules  []ScheduleInfo    `json:"schedules"`
+	Counts     map[JobStatus]int `json:"counts"`
+}
+
+// ScheduleInfo describes a recurring job
+type ScheduleInfo struct {
+	Name     string        `json:"name"`
+	Type     string        `json:"type"`
+	Interval time.Duration `json:"interval"`
+	NextRun  time.Time     `json:"next_run"`
+}
@@ -0,0 +1,467 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yourusername/go-web-api/internal/utils"
+
+	"github.com/rs/zerolog"
+)
+
+var (
+	ErrUnknownJobType = errors.New("no handler registered for job type")
+	ErrQueueFull      = errors.New("job queue is full")
+	ErrJobNotFound    = errors.New("job not found")
+)
+
+const (
+	// retryBaseDelay is the delay before the first retry, doubled on every attempt
+	retryBaseDelay = time.Second
+	// retryMaxDelay caps the exponential retry backoff
+	retryMaxDelay = 5 * time.Minute
+)
+
+// Config holds worker configuration
+type Config struct {
+	Concurrency int
+	QueueSize   int
+	MaxRetries  int
+	HistorySize int
+}
+
+// Worker runs registered job handlers on a pool of goroutines fed by an
+// in-memory channel. Job state is kept in memory for the status endpoint;
+// jobs do not survive a restart.
+type Worker struct {
+	cfg    Config
+	logger *zerolog.Logger
+	queue  chan *Job
+
+	mu        sync.RWMutex
+	handlers  map[string]HandlerFunc
+	jobs      map[string]*Job
+	finished  []string
+	schedules []*schedule
+	onFinish  []func(Job)
+
+	// quit stops the dispatch and schedule loops; ctx is passed to handlers
+	// and is only cancelled when running jobs do not finish in time
+	quit     context.Context
+	stopLoop context.CancelFunc
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	started  bool
+}
+
+type schedule struct {
+	name     string
+	jobType  string
+	interval time.Duration
+	payload  json.RawMessage
+	nextRun  time.Time
+}
+
+// New creates a new worker
+func New(cfg Config, logger *zerolog.Logger) *Worker {
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	if cfg.QueueSize < 1 {
+		cfg.QueueSize = 100
+	}
+	if cfg.HistorySize < 1 {
+		cfg.HistorySize = 1000
+	}
+
+	quit, stopLoop := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Worker{
+		cfg:      cfg,
+		logger:   logger,
+		queue:    make(chan *Job, cfg.QueueSize),
+		handlers: make(map[string]HandlerFunc),
+		jobs:     make(map[string]*Job),
+		quit:     quit,
+		stopLoop: stopLoop,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Register registers the handler for a job type
+func (w *Worker) Register(jobType string, handler HandlerFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers[jobType] = handler
+}
+
+// OnFinish registers a function called with a copy of every job that
+// succeeds or fails permanently. It is called synchronously and must not
+// block.
+func (w *Worker) OnFinish(fn func(job Job)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onFinish = append(w.onFinish, fn)
+}
+
+// Enqueue adds a job to the queue. The payload is encoded as JSON.
+func (w *Worker) Enqueue(jobType string, payload interface{}, opts ...Option) (*Job, error) {
+	w.mu.RLock()
+	_, ok := w.handlers[jobType]
+	w.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownJobType, jobType)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	id, err := utils.GenerateRandomToken(8)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate job ID: %w", err)
+	}
+
+	now := time.Now().UTC()
+	job := &Job{
+		ID:         id,
+		Type:       jobType,
+		Payload:    data,
+		Status:     StatusQueued,
+		MaxRetries: w.cfg.MaxRetries,
+		RunAt:      now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+
+	if job.RunAt.After(now) {
+		job.Status = StatusScheduled
+		snapshot := job.snapshot()
+		w.store(job)
+		w.dispatchAt(job, job.RunAt.Sub(now))
+		return snapshot, nil
+	}
+
+	// Take the snapshot before a worker can pick the job up
+	snapshot := job.snapshot()
+	w.store(job)
+	select {
+	case w.queue <- job:
+	default:
+		w.finish(job, StatusFailed, ErrQueueFull)
+		return nil, ErrQueueFull
+	}
+
+	return snapshot, nil
+}
+
+// Schedule registers a recurring job enqueued every interval once the worker is started
+func (w *Worker) Schedule(name string, interval time.Duration, jobType string, payload interface{}) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid interval for schedule %s", name)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.handlers[jobType]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownJobType, jobType)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	w.schedules = append(w.schedules, &schedule{
+		name:     name,
+		jobType:  jobType,
+		interval: interval,
+		payload:  data,
+	})
+
+	return nil
+}
+
+// Start launches the worker goroutines and the schedulers
+func (w *Worker) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.started {
+		return
+	}
+	w.started = true
+
+	for i := 0; i < w.cfg.Concurrency; i++ {
+		w.wg.Add(1)
+		go w.run()
+	}
+
+	for _, s := range w.schedules {
+		s.nextRun = time.Now().UTC().Add(s.interval)
+		w.wg.Add(1)
+		go w.runSchedule(s)
+	}
+
+	w.logger.Info().
+		Int("workers", w.cfg.Concurrency).
+		Int("schedules", len(w.schedules)).
+		Msg("Background worker started")
+}
+
+// Stop stops picking up queued and scheduled jobs and waits for running jobs
+// to finish. If the context expires first, the context of the running jobs is
+// cancelled so their handlers can abort.
+func (w *Worker) Stop(ctx context.Context) error {
+	w.stopLoop()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		w.cancel()
+		w.logger.Info().Msg("Background worker stopped")
+		return nil
+	case <-ctx.Done():
+		w.cancel()
+		return fmt.Errorf("worker did not stop in time: %w", ctx.Err())
+	}
+}
+
+// Job returns a snapshot of a job by ID
+func (w *Worker) Job(id string) (*Job, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	job, ok := w.jobs[id]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	return job.snapshot(), nil
+}
+
+// Jobs returns snapshots of the known jobs, newest first, optionally filtered by status
+func (w *Worker) Jobs(status JobStatus) []Job {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	jobs := make([]Job, 0, len(w.jobs))
+	for _, job := range w.jobs {
+		if status == "" || job.Status == status {
+			jobs = append(jobs, *job.snapshot())
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+
+	return jobs
+}
+
+// Stats returns a snapshot of worker activity
+func (w *Worker) Stats() Stats {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	stats := Stats{
+		Workers:    w.cfg.Concurrency,
+		QueueDepth: len(w.queue),
+		Registered: make([]string, 0, len(w.handlers)),
+		Schedules:  make([]ScheduleInfo, 0, len(w.schedules)),
+		Counts:     make(map[JobStatus]int),
+	}
+
+	for jobType := range w.handlers {
+		stats.Registered = append(stats.Registered, jobType)
+	}
+	sort.Strings(stats.Registered)
+
+	for _, s := range w.schedules {
+		stats.Schedules = append(stats.Schedules, ScheduleInfo{
+			Name:     s.name,
+			Type:     s.jobType,
+			Interval: s.interval,
+			NextRun:  s.nextRun,
+		})
+	}
+
+	for _, job := range w.jobs {
+		stats.Counts[job.Status]++
+	}
+
+	return stats
+}
+
+// run processes jobs from the queue until the worker is stopped
+func (w *Worker) run() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.quit.Done():
+			return
+		case job := <-w.queue:
+			w.process(job)
+		}
+	}
+}
+
+// process runs a single attempt of a job and schedules a retry on failure
+func (w *Worker) process(job *Job) {
+	w.mu.Lock()
+	handler := w.handlers[job.Type]
+	job.Status = StatusRunning
+	job.Attempts++
+	job.UpdatedAt = time.Now().UTC()
+	w.mu.Unlock()
+
+	err := w.execute(handler, job)
+	if err == nil {
+		w.finish(job, StatusSucceeded, nil)
+		return
+	}
+
+	logEvent := w.logger.Warn()
+	if job.Attempts > job.MaxRetries {
+		logEvent = w.logger.Error()
+	}
+	logEvent.
+		Err(err).
+		Str("job_id", job.ID).
+		Str("job_type", job.Type).
+		Int("attempt", job.Attempts).
+		Msg("Job failed")
+
+	if job.Attempts > job.MaxRetries {
+		w.finish(job, StatusFailed, err)
+		return
+	}
+
+	w.mu.Lock()
+	job.Status = StatusRetrying
+	job.LastError = err.Error()
+	job.UpdatedAt = time.Now().UTC()
+	w.mu.Unlock()
+
+	w.dispatchAt(job, retryDelay(job.Attempts))
+}
+
+// execute calls the handler, converting panics into errors
+func (w *Worker) execute(handler HandlerFunc, job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return handler(w.ctx, job.Payload)
+}
+
+// dispatchAt pushes a job onto the queue after a delay
+func (w *Worker) dispatchAt(job *Job, delay time.Duration) {
+	w.mu.Lock()
+	job.RunAt = time.Now().UTC().Add(delay)
+	w.mu.Unlock()
+
+	time.AfterFunc(delay, func() {
+		w.mu.Lock()
+		if job.Status == StatusScheduled {
+			job.Status = StatusQueued
+		}
+		w.mu.Unlock()
+
+		select {
+		case w.queue <- job:
+		case <-w.quit.Done():
+		}
+	})
+}
+
+// runSchedule enqueues a recurring job on every tick
+func (w *Worker) runSchedule(s *schedule) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.quit.Done():
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			s.nextRun = time.Now().UTC().Add(s.interval)
+			w.mu.Unlock()
+
+			if _, err := w.Enqueue(s.jobType, s.payload); err != nil {
+				w.logger.Error().Err(err).Str("schedule", s.name).Msg("Failed to enqueue scheduled job")
+			}
+		}
+	}
+}
+
+// store records a job for status reporting
+func (w *Worker) store(job *Job) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.jobs[job.ID] = job
+}
+
+// finish marks a job as done, trims the finished job history and notifies
+// the OnFinish callbacks
+func (w *Worker) finish(job *Job, status JobStatus, err error) {
+	w.mu.Lock()
+
+	job.Status = status
+	job.UpdatedAt = time.Now().UTC()
+	job.LastError = ""
+	if err != nil {
+		job.LastError = err.Error()
+	}
+
+	w.finished = append(w.finished, job.ID)
+	for len(w.finished) > w.cfg.HistorySize {
+		delete(w.jobs, w.finished[0])
+		w.finished = w.finished[1:]
+	}
+
+	done := *job.snapshot()
+	callbacks := w.onFinish
+	w.mu.Unlock()
+
+	for _, fn := range callbacks {
+		fn(done)
+	}
+}
+
+// snapshot returns a copy of the job that is safe to hand out
+func (j *Job) snapshot() *Job {
+	c := *j
+	return &c
+}
+
+// retryDelay returns the exponential backoff delay after the given attempt
+func retryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay << (attempt - 1)
+	if delay <= 0 || delay > retryMaxDelay {
+		return retryMaxDelay
+	}
+	return delay
+}
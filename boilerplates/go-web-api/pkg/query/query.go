@@ -0,0 +1,168 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrInvalidPage        = errors.New("page must be a positive integer")
+	ErrPageTooLarge       = errors.New("page is too large")
+	ErrInvalidLimit       = errors.New("limit must be a positive integer")
+	ErrInvalidSortField   = errors.New("invalid sort field")
+	ErrInvalidFilterField = errors.New("invalid filter field")
+	ErrInvalidFilterValue = errors.New("invalid filter value")
+)
+
+// FilterType is the type a filter value is parsed as before it reaches the query
+type FilterType int
+
+const (
+	FilterString FilterType = iota
+	FilterInt
+	FilterBool
+	FilterTime // RFC 3339
+)
+
+// FilterField maps a public filter name to its database column and value type
+type FilterField struct {
+	Column string
+	Type   FilterType
+}
+
+// Options describes which list parameters an endpoint accepts. Sortable and
+// filterable fields map the public query name to the database column, so only
+// whitelisted columns ever reach the query. Filter values are parsed as the
+// field's type so malformed values are rejected before reaching the database.
+// MaxPage bounds the offset the database has to skip.
+type Options struct {
+	DefaultLimit     int
+	MaxLimit         int
+	MaxPage          int
+	DefaultSort      string
+	SortableFields   map[string]string
+	FilterableFields map[string]FilterField
+}
+
+// SortField represents a single column to order by
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// Filter represents an equality filter on a column
+type Filter struct {
+	Column string
+	Value  interface{}
+}
+
+// Params holds the parsed list parameters of a request
+type Params struct {
+	Page    int
+	Limit   int
+	Sort    []SortField
+	Filters []Filter
+}
+
+// Offset returns the number of rows to skip for the current page
+func (p *Params) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+// Parse reads page, limit, sort and filter query parameters from the request.
+//
+// Supported formats:
+//
+//	?page=2&limit=20
+//	?sort=-created_at,username   (prefix with "-" for descending)
+//	?filter[is_active]=true
+func Parse(c *gin.Context, opts Options) (*Params, error) {
+	if opts.DefaultLimit < 1 {
+		opts.DefaultLimit = 10
+	}
+	if opts.MaxLimit < 1 {
+		opts.MaxLimit = 100
+	}
+	if opts.MaxPage < 1 {
+		opts.MaxPage = 10000
+	}
+
+	params := &Params{Page: 1, Limit: opts.DefaultLimit}
+
+	if value := c.Query("page"); value != "" {
+		page, err := strconv.Atoi(value)
+		if err != nil || page < 1 {
+			return nil, ErrInvalidPage
+		}
+		if page > opts.MaxPage {
+			return nil, fmt.Errorf("%w: maximum is %d", ErrPageTooLarge, opts.MaxPage)
+		}
+		params.Page = page
+	}
+
+	// Accept page_size as an alias of limit
+	limitValue := c.Query("limit")
+	if limitValue == "" {
+		limitValue = c.Query("page_size")
+	}
+	if limitValue != "" {
+		limit, err := strconv.Atoi(limitValue)
+		if err != nil || limit < 1 {
+			return nil, ErrInvalidLimit
+		}
+		if limit > opts.MaxLimit {
+			limit = opts.MaxLimit
+		}
+		params.Limit = limit
+	}
+
+	sortValue := c.DefaultQuery("sort", opts.DefaultSort)
+	for _, field := range strings.Split(sortValue, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		desc := strings.HasPrefix(field, "-")
+		name := strings.TrimPrefix(field, "-")
+
+		column, ok := opts.SortableFields[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSortField, name)
+		}
+		params.Sort = append(params.Sort, SortField{Column: column, Desc: desc})
+	}
+
+	for name, value := range c.QueryMap("filter") {
+		field, ok := opts.FilterableFields[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidFilterField, name)
+		}
+		parsed, err := parseFilterValue(field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidFilterValue, name)
+		}
+		params.Filters = append(params.Filters, Filter{Column: field.Column, Value: parsed})
+	}
+
+	return params, nil
+}
+
+// parseFilterValue converts a filter value to the type of its field
+func parseFilterValue(t FilterType, value string) (interface{}, error) {
+	switch t {
+	case FilterInt:
+		return strconv.ParseInt(value, 10, 64)
+	case FilterBool:
+		return strconv.ParseBool(value)
+	case FilterTime:
+		return time.Parse(time.RFC3339, value)
+	default:
+		return value, nil
+	}
+}
@@ -1,6 +1,10 @@
 package response
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -9,23 +13,46 @@ type Response struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Error   *ErrorBody  `json:"error,omitempty"`
+}
+
+// ErrorBody represents the error details of a failed request
+type ErrorBody struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError represents a validation error on a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
-// PaginatedResponse represents a paginated API response
-type PaginatedResponse struct {
-	Success    bool        `json:"success"`
-	Message    string      `json:"message"`
-	Data       interface{} `json:"data"`
-	Pagination Pagination  `json:"pagination"`
+// ListResponse represents a paginated list API response
+type ListResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+	Meta    ListMeta    `json:"meta"`
+	Links   ListLinks   `json:"links"`
 }
 
-// Pagination represents pagination metadata
-type Pagination struct {
-	Page       int `json:"page"`
-	PageSize   int `json:"page_size"`
-	TotalItems int `json:"total_items"`
-	TotalPages int `json:"total_pages"`
+// ListMeta represents pagination metadata
+type ListMeta struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// ListLinks represents navigation links between pages
+type ListLinks struct {
+	Self  string `json:"self"`
+	First string `json:"first"`
+	Last  string `json:"last"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
 }
 
 // Success sends a successful response
@@ -37,33 +64,75 @@ func Success(c *gin.Context, statusCode int, message string, data interface{}) {
 	})
 }
 
-// Error sends an error response
+// Error sends an error response with a code derived from the status code
 func Error(c *gin.Context, statusCode int, message string, err error) {
-	resp := Response{
-		Success: false,
-		Message: message,
-	}
-
+	detail := message
 	if err != nil {
-		resp.Error = err.Error()
+		detail = err.Error()
 	}
 
-	c.JSON(statusCode, resp)
+	Fail(c, statusCode, StatusCode(statusCode), message, detail, nil)
 }
 
-// Paginated sends a paginated response
-func Paginated(c *gin.Context, statusCode int, message string, data interface{}, page, pageSize, total int) {
-	totalPages := (total + pageSize - 1) / pageSize
+// Fail sends an error response with an explicit error code and optional field errors
+func Fail(c *gin.Context, statusCode int, code, message, detail string, fields []FieldError) {
+	c.JSON(statusCode, Response{
+		Success: false,
+		Message: message,
+		Error: &ErrorBody{
+			Code:    code,
+			Message: detail,
+			Fields:  fields,
+		},
+	})
+}
+
+// StatusCode returns the default error code for an HTTP status, e.g. NOT_FOUND
+func StatusCode(statusCode int) string {
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(statusCode), " ", "_"))
+}
+
+// List sends a paginated list response with metadata and page links
+func List(c *gin.Context, statusCode int, message string, data interface{}, page, limit int, total int64) {
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	lastPage := totalPages
+	if lastPage < 1 {
+		lastPage = 1
+	}
 
-	c.JSON(statusCode, PaginatedResponse{
+	links := ListLinks{
+		Self:  pageURL(c, page, limit),
+		First: pageURL(c, 1, limit),
+		Last:  pageURL(c, lastPage, limit),
+	}
+	if page > 1 {
+		links.Prev = pageURL(c, page-1, limit)
+	}
+	if page < totalPages {
+		links.Next = pageURL(c, page+1, limit)
+	}
+
+	c.JSON(statusCode, ListResponse{
 		Success: true,
 		Message: message,
 		Data:    data,
-		Pagination: Pagination{
+		Meta: ListMeta{
 			Page:       page,
-			PageSize:   pageSize,
-			TotalItems: total,
+			Limit:      limit,
+			Total:      total,
 			TotalPages: totalPages,
 		},
+		Links: links,
 	})
 }
+
+// pageURL returns the current request URL pointing at the given page
+func pageURL(c *gin.Context, page, limit int) string {
+	u := *c.Request.URL
+	q := u.Query()
+	q.Del("page_size")
+	q.Set("page", strconv.Itoa(page))
+	q.Set("limit", strconv.Itoa(limit))
+	u.RawQuery = q.Encode()
+	return u.RequestURI()
+}